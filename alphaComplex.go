@@ -0,0 +1,490 @@
+package comptop
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// DelaunayComplex returns the Delaunay triangulation of the points in Euclidean space, together with all of its faces.
+// The i^th point becomes the 0-simplex with Index i; repeated points are left as isolated vertices.
+// The Data field of every Simplex holds its alpha value as a float64: the radius at which it enters the alpha filtration.
+// Filtering by it gives the alpha filtration, whose sub-complexes have the same homology as the Čech complexes at the same radius.
+//
+// The triangulation is built with the Bowyer-Watson algorithm, closing off the convex hull with a symbolic vertex at infinity.
+// Points are assumed to be in general position: no d+2 of them on a common sphere.
+// An error is returned if the points do not all have the same dimension or if they lie in a lower dimensional affine subspace.
+//
+// More info: https://en.wikipedia.org/wiki/Delaunay_triangulation
+func DelaunayComplex(points [][]float64) (*Complex, error) {
+	c := &Complex{}
+	if len(points) == 0 {
+		return c, nil
+	}
+
+	dim := len(points[0])
+	for idx, p := range points {
+		if len(p) != dim {
+			return nil, fmt.Errorf("comptop: DelaunayComplex: point %d has dimension %d, expected %d", idx, len(p), dim)
+		}
+	}
+
+	if dim == 0 || affineRank(points) < dim {
+		return nil, fmt.Errorf("comptop: DelaunayComplex: the points do not span a %d-dimensional space", dim)
+	}
+
+	bases := []Base{}
+	for i := range points {
+		bases = append(bases, Base{Index(i)})
+	}
+	for _, cell := range bowyerWatson(points) {
+		b := make(Base, len(cell))
+		for idx, v := range cell {
+			b[idx] = Index(v)
+		}
+		bases = append(bases, b)
+	}
+
+	c.NewSimplices(bases...)
+	c.setAlphaValues(points)
+
+	return c, nil
+}
+
+// AlphaComplex returns the alpha complex of the points in Euclidean space at scale r:
+// the sub-complex of their DelaunayComplex made of the simplices with an alpha value of at most r.
+// It has the same homology as the Čech complex at scale r, using far fewer simplices.
+// The Data field of every Simplex holds its alpha value as a float64.
+// Errors are returned as by DelaunayComplex.
+//
+// More info: https://en.wikipedia.org/wiki/Alpha_shape
+func AlphaComplex(points [][]float64, r float64) (*Complex, error) {
+	delaunay, err := DelaunayComplex(points)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Complex{}
+	bases := []Base{}
+	for d := Dim(0); d <= delaunay.dim; d++ {
+		for _, smplx := range delaunay.GetdSimplices(d) {
+			if smplx.Data.(float64) <= r {
+				bases = append(bases, smplx.Base())
+			}
+		}
+	}
+	c.NewSimplices(bases...)
+
+	for d := Dim(0); d <= c.dim; d++ {
+		for _, smplx := range c.GetdSimplices(d) {
+			smplx.Data = delaunay.GetSimplex(smplx.base...).Data
+		}
+	}
+
+	return c, nil
+}
+
+// setAlphaValues sets the Data of every Simplex of the Delaunay triangulation c to its alpha value, from the top dimension down.
+// A Simplex whose smallest circumsphere has no vertex of its cofacets inside enters at the radius of that sphere;
+// otherwise it is attached to a cofacet and enters with the earliest of its cofacets.
+//
+// More info: 'Computational Topology: An Introduction' by Edelsbrunner & Harer, pg 71.
+func (c *Complex) setAlphaValues(points [][]float64) {
+	cofacets := map[*Simplex][]*Simplex{}
+	for d := Dim(1); d <= c.dim; d++ {
+		for _, smplx := range c.GetdSimplices(d) {
+			for _, facet := range facetsOf(smplx) {
+				cofacets[facet] = append(cofacets[facet], smplx)
+			}
+		}
+	}
+
+	for d := int(c.dim); d >= 0; d-- {
+		for _, smplx := range c.GetdSimplices(Dim(d)) {
+			center, radius, _ := circumsphere(pointsOf(points, smplx.base))
+
+			attached := false
+			earliest := math.Inf(1)
+			for _, cofacet := range cofacets[smplx] {
+				earliest = math.Min(earliest, cofacet.Data.(float64))
+
+				for _, v := range cofacet.base {
+					if !smplx.base.has(v) && squaredDistance(center, points[v]) < radius*radius*(1-1e-12) {
+						attached = true
+					}
+				}
+			}
+
+			if attached {
+				smplx.Data = earliest
+			} else {
+				smplx.Data = radius
+			}
+		}
+	}
+}
+
+// has returns true if v is a vertex of b.
+func (b Base) has(v Index) bool {
+	for _, u := range b {
+		if u == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bowyerWatson returns the full dimensional simplices of the Delaunay triangulation of the points, as sorted point indices.
+// Each point is inserted in turn by removing the cells whose circumsphere contains it and joining it to the boundary of the resulting cavity.
+// The cavity is connected: it is found by walking through adjacent cells to a cell containing the point, and spreading from there.
+//
+// The triangulation is closed off by cells joining each facet of the convex hull to a symbolic vertex at infinity,
+// which a point conflicts with when it lies beyond their facet.
+// Unlike an enclosing simplex with finite vertices, this never cuts off hull cells with huge circumspheres, such as those of nearly collinear points.
+//
+// More info: 'Computational Geometry: Algorithms and Applications' by de Berg, Cheong, van Kreveld & Overmars, pg 191.
+func bowyerWatson(points [][]float64) [][]int {
+	n := len(points)
+	dim := len(points[0])
+
+	type cell struct {
+		// vertices are sorted, so the vertex at infinity comes first; neighbors[i] is the cell across the facet opposite vertices[i]
+		vertices  []int
+		neighbors []*cell
+
+		center []float64
+		r2     float64
+
+		// normal and offset give the hull facet of a cell on the vertex at infinity: dot(normal, x) > offset beyond it
+		normal []float64
+		offset float64
+
+		// flat cells have no circumsphere (or hull facet) and conflict with every point
+		flat bool
+		dead bool
+	}
+
+	// The first d+1 affinely independent points form the starting cell; its centroid stays inside the hull as it grows
+	first := []int{0}
+	for p := 1; p < n && len(first) <= dim; p++ {
+		ps := [][]float64{points[p]}
+		for _, v := range first {
+			ps = append(ps, points[v])
+		}
+		if affineRank(ps) == len(first) {
+			first = append(first, p)
+		}
+	}
+
+	inside := make([]float64, dim)
+	for _, v := range first {
+		for j := range inside {
+			inside[j] += points[v][j] / float64(dim+1)
+		}
+	}
+	var spread float64
+	for _, p := range points {
+		spread = math.Max(spread, math.Sqrt(squaredDistance(p, inside)))
+	}
+	tol := 1e-12 * spread
+
+	newCell := func(vertices []int) *cell {
+		sort.Ints(vertices)
+		cl := &cell{vertices: vertices, neighbors: make([]*cell, len(vertices))}
+
+		ps := [][]float64{}
+		for _, v := range vertices {
+			if v != infiniteVertex {
+				ps = append(ps, points[v])
+			}
+		}
+
+		center, radius, ok := circumsphere(ps)
+		cl.center, cl.r2, cl.flat = center, radius*radius, !ok
+
+		if vertices[0] == infiniteVertex && ok {
+			cl.normal, ok = hyperplaneNormal(ps)
+			cl.flat = !ok
+			if ok {
+				cl.offset = dot(cl.normal, ps[0])
+				if dot(cl.normal, inside) > cl.offset {
+					for j := range cl.normal {
+						cl.normal[j] = -cl.normal[j]
+					}
+					cl.offset = -cl.offset
+				}
+			}
+		}
+
+		return cl
+	}
+
+	conflicts := func(cl *cell, p []float64) bool {
+		if cl.flat {
+			return true
+		}
+
+		if cl.vertices[0] == infiniteVertex {
+			side := dot(cl.normal, p) - cl.offset
+			if side > tol {
+				return true
+			}
+			if side < -tol {
+				return false
+			}
+		}
+
+		// Points on the hyperplane of a hull facet conflict with it if they are inside its circumsphere
+		return squaredDistance(cl.center, p) < cl.r2*(1-1e-12)
+	}
+
+	// link makes neighbors of the cells which share a facet
+	link := func(cells []*cell) {
+		facets := map[string]*cell{}
+		opposite := map[string]int{}
+		for _, cl := range cells {
+			for skip := range cl.vertices {
+				facet := make([]int, 0, dim)
+				for idx, v := range cl.vertices {
+					if idx != skip {
+						facet = append(facet, v)
+					}
+				}
+
+				key := fmt.Sprint(facet)
+				if other, exists := facets[key]; exists {
+					cl.neighbors[skip] = other
+					other.neighbors[opposite[key]] = cl
+					continue
+				}
+				facets[key] = cl
+				opposite[key] = skip
+			}
+		}
+	}
+
+	cells := []*cell{newCell(append([]int(nil), first...))}
+	for skip := range first {
+		vertices := []int{infiniteVertex}
+		for idx, v := range first {
+			if idx != skip {
+				vertices = append(vertices, v)
+			}
+		}
+		cells = append(cells, newCell(vertices))
+	}
+	link(cells)
+	last := cells[0]
+	alive := len(cells)
+
+	// locate returns a cell conflicting with p: it walks towards p from the last cell created,
+	// stopping at the cell containing p or at the cell beyond the hull facet it leaves through
+	locate := func(p []float64) *cell {
+		cl := last
+		if cl.vertices[0] == infiniteVertex {
+			cl = cl.neighbors[0]
+		}
+
+		for steps := 0; steps < alive && !cl.flat && cl.vertices[0] != infiniteVertex; steps++ {
+			next := barycentricExit(points, cl.vertices, p)
+			if next < 0 {
+				break
+			}
+			cl = cl.neighbors[next]
+		}
+
+		if conflicts(cl, p) {
+			return cl
+		}
+
+		// Rounding may stop the walk short of a conflicting cell
+		for _, cl := range cells {
+			if !cl.dead && conflicts(cl, p) {
+				return cl
+			}
+		}
+
+		return nil
+	}
+
+	isFirst := map[int]bool{}
+	for _, v := range first {
+		isFirst[v] = true
+	}
+
+	for p := 0; p < n; p++ {
+		if isFirst[p] {
+			continue
+		}
+
+		start := locate(points[p])
+		if start == nil {
+			// p repeats a point already inserted
+			continue
+		}
+
+		// The cavity is the connected region of conflicting cells around start
+		cavity := map[*cell]bool{start: true}
+		queue := []*cell{start}
+		added := []*cell{}
+		for len(queue) > 0 {
+			cl := queue[0]
+			queue = queue[1:]
+
+			for skip, neighbor := range cl.neighbors {
+				if cavity[neighbor] {
+					continue
+				}
+				if conflicts(neighbor, points[p]) {
+					cavity[neighbor] = true
+					queue = append(queue, neighbor)
+					continue
+				}
+
+				// The facet between cl and neighbor is on the boundary of the cavity; join it to p
+				vertices := []int{p}
+				for idx, v := range cl.vertices {
+					if idx != skip {
+						vertices = append(vertices, v)
+					}
+				}
+				nc := newCell(vertices)
+				for idx, v := range nc.vertices {
+					if v == p {
+						nc.neighbors[idx] = neighbor
+					}
+				}
+				for idx, other := range neighbor.neighbors {
+					if other == cl {
+						neighbor.neighbors[idx] = nc
+					}
+				}
+				added = append(added, nc)
+			}
+		}
+
+		for cl := range cavity {
+			cl.dead = true
+		}
+		link(added)
+
+		cells = append(cells, added...)
+		alive += len(added) - len(cavity)
+		last = added[0]
+	}
+
+	delaunay := [][]int{}
+	for _, cl := range cells {
+		if !cl.dead && !cl.flat && cl.vertices[0] != infiniteVertex {
+			delaunay = append(delaunay, cl.vertices)
+		}
+	}
+
+	return delaunay
+}
+
+// infiniteVertex stands for the vertex at infinity in the cells built by bowyerWatson.
+const infiniteVertex = -1
+
+// barycentricExit returns the position of the vertex of the full dimensional simplex on the given points whose barycentric coordinate for p is the most negative,
+// which is the vertex opposite a facet that p lies beyond; returns -1 if p is in the simplex or the simplex is flat.
+func barycentricExit(points [][]float64, vertices []int, p []float64) int {
+	dim := len(p)
+	simplex := make([][]float64, len(vertices))
+	for idx, v := range vertices {
+		simplex[idx] = points[v]
+	}
+
+	t := mat.NewDense(dim, dim, nil)
+	b := mat.NewVecDense(dim, nil)
+	for j := 0; j < dim; j++ {
+		for i := 1; i <= dim; i++ {
+			t.Set(j, i-1, simplex[i][j]-simplex[0][j])
+		}
+		b.SetVec(j, p[j]-simplex[0][j])
+	}
+
+	var l mat.VecDense
+	if err := l.SolveVec(t, b); err != nil {
+		return -1
+	}
+
+	exit, min := -1, 0.0
+	l0 := 1.0
+	for i := 1; i <= dim; i++ {
+		l0 -= l.AtVec(i - 1)
+		if x := l.AtVec(i - 1); x < min {
+			exit, min = i, x
+		}
+	}
+	if l0 < min {
+		exit = 0
+	}
+
+	return exit
+}
+
+// hyperplaneNormal returns a unit normal of the hyperplane through the d points in d-dimensional space;
+// returns false if they don't span a hyperplane.
+func hyperplaneNormal(points [][]float64) ([]float64, bool) {
+	dim := len(points[0])
+	if dim == 1 {
+		return []float64{1}, true
+	}
+
+	diffs := mat.NewDense(dim, dim, nil)
+	for i := 1; i < len(points); i++ {
+		for j := 0; j < dim; j++ {
+			diffs.Set(i-1, j, points[i][j]-points[0][j])
+		}
+	}
+
+	// The normal spans the kernel of the differences, the right singular vector of the smallest singular value
+	var svd mat.SVD
+	if !svd.Factorize(diffs, mat.SVDFull) {
+		return nil, false
+	}
+
+	values := svd.Values(nil)
+	if values[dim-2] <= 1e-9*values[0] {
+		return nil, false
+	}
+
+	var v mat.Dense
+	svd.VTo(&v)
+
+	return mat.Col(nil, dim-1, &v), true
+}
+
+// affineRank returns the dimension of the affine hull of the points.
+func affineRank(points [][]float64) int {
+	if len(points) < 2 {
+		return 0
+	}
+
+	dim := len(points[0])
+	diffs := mat.NewDense(len(points)-1, dim, nil)
+	for i := 1; i < len(points); i++ {
+		for j := 0; j < dim; j++ {
+			diffs.Set(i-1, j, points[i][j]-points[0][j])
+		}
+	}
+
+	var svd mat.SVD
+	if !svd.Factorize(diffs, mat.SVDNone) {
+		return 0
+	}
+
+	values := svd.Values(nil)
+	var rank int
+	for _, s := range values {
+		if s > 1e-9*values[0] {
+			rank++
+		}
+	}
+
+	return rank
+}
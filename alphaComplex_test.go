@@ -0,0 +1,276 @@
+package comptop
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestDelaunayComplex(t *testing.T) {
+	for _, dim := range []int{2, 3} {
+		rng := rand.New(rand.NewSource(int64(dim)))
+		points := make([][]float64, 25)
+		for i := range points {
+			points[i] = make([]float64, dim)
+			for j := range points[i] {
+				points[i][j] = rng.Float64()
+			}
+		}
+
+		c, err := DelaunayComplex(points)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if c.dim != Dim(dim) {
+			t.Fatalf("expected dimension %d, got %d", dim, c.dim)
+		}
+
+		// The triangulation of a convex hull is contractible
+		bn := c.BettiNumbers()
+		for d, b := range bn {
+			if (d == 0 && b != 1) || (d > 0 && b != 0) {
+				t.Errorf("expected a contractible triangulation in dimension %d, got Betti numbers %v", dim, bn)
+				break
+			}
+		}
+
+		// Every cell has an empty circumsphere
+		for _, cell := range c.GetdSimplices(Dim(dim)) {
+			center, radius, _ := circumsphere(pointsOf(points, cell.base))
+			for v, p := range points {
+				if !cell.base.has(Index(v)) && math.Sqrt(squaredDistance(center, p)) < radius-1e-9 {
+					t.Errorf("point %d is inside the circumsphere of %v", v, cell)
+				}
+			}
+		}
+
+		// The cells cover the convex hull without overlapping
+		var volume float64
+		for _, cell := range c.GetdSimplices(Dim(dim)) {
+			volume += simplexVolume(pointsOf(points, cell.base))
+		}
+		if dim == 2 {
+			if area := hullArea(points); math.Abs(volume-area) > 1e-9 {
+				t.Errorf("expected the triangles to cover the hull of area %v, got %v", area, volume)
+			}
+		} else if volume <= 0 || volume > 1 {
+			t.Errorf("expected a volume in (0, 1], got %v", volume)
+		}
+
+		// Alpha values never decrease from a face to its cofaces
+		for d := Dim(1); d <= c.dim; d++ {
+			for _, smplx := range c.GetdSimplices(d) {
+				for _, facet := range facetsOf(smplx) {
+					if facet.Data.(float64) > smplx.Data.(float64)+1e-12 {
+						t.Errorf("expected %v to enter before %v", facet, smplx)
+					}
+				}
+			}
+		}
+	}
+
+	if _, err := DelaunayComplex([][]float64{{0, 0}, {1, 1}, {2, 2}}); err == nil {
+		t.Error("expected an error for collinear points")
+	}
+	if _, err := DelaunayComplex([][]float64{{0, 0}, {1}}); err == nil {
+		t.Error("expected an error for points of different dimensions")
+	}
+}
+
+func TestDelaunayComplex_Hull(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	random := func(n, dim int) [][]float64 {
+		points := make([][]float64, n)
+		for i := range points {
+			points[i] = make([]float64, dim)
+			for j := range points[i] {
+				points[i][j] = rng.Float64()
+			}
+		}
+
+		return points
+	}
+
+	// Nearly collinear points on the hull have circumspheres far larger than the spread of the points
+	table := []struct {
+		name          string
+		points        [][]float64
+		expectedCells int
+	}{
+		{"thin triangle", [][]float64{{0, 0}, {0.5, -1e-6}, {1, 0}}, 1},
+		{"flat hull", [][]float64{{0, 0}, {1, -1e-7}, {2, -1.5e-7}, {3, -1e-7}, {4, 0}, {2, 1}}, 4},
+		{"line", [][]float64{{3}, {0}, {1}, {1}, {2.5}}, 3},
+		{"many points", append(random(400, 2), []float64{0.5, 0.5}, []float64{0.5, 0.5}), -1},
+		{"many points in space", random(120, 3), -1},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(tt *testing.T) {
+			dim := len(row.points[0])
+			c, err := DelaunayComplex(row.points)
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+
+			cells := c.GetdSimplices(Dim(dim))
+			if row.expectedCells >= 0 && len(cells) != row.expectedCells {
+				tt.Fatalf("expected %d cells, got %v", row.expectedCells, cells)
+			}
+
+			for _, cell := range cells {
+				center, radius, _ := circumsphere(pointsOf(row.points, cell.base))
+				for v, p := range row.points {
+					if !cell.base.has(Index(v)) && math.Sqrt(squaredDistance(center, p)) < radius*(1-1e-9) {
+						tt.Errorf("point %d is inside the circumsphere of %v", v, cell)
+					}
+				}
+			}
+
+			if dim != 2 {
+				return
+			}
+
+			var volume float64
+			for _, cell := range cells {
+				volume += simplexVolume(pointsOf(row.points, cell.base))
+			}
+			if area := hullArea(row.points); math.Abs(volume-area) > 1e-9*area {
+				tt.Errorf("expected the triangles to cover the hull of area %v, got %v", area, volume)
+			}
+		})
+	}
+}
+
+func TestAlphaComplex(t *testing.T) {
+	// Points near the unit circle, slightly perturbed to be in general position
+	points := [][]float64{}
+	for k := 0; k < 12; k++ {
+		theta := 2 * math.Pi * float64(k) / 12
+		r := 1 + 0.01*math.Sin(float64(3*k+1))
+		points = append(points, []float64{r * math.Cos(theta), r * math.Sin(theta)})
+	}
+	points = append(points, points[0])
+
+	table := []struct {
+		r          float64
+		expectedBN []int
+	}{
+		{0.1, []int{12}},
+		{0.4, []int{1, 1}},
+		{1.5, []int{1, 0, 0}},
+	}
+
+	for _, row := range table {
+		c, err := AlphaComplex(points, row.r)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// The repeated point is an isolated vertex
+		bn := c.BettiNumbers()
+		bn[0]--
+
+		if len(bn) != len(row.expectedBN) {
+			t.Fatalf("expected Betti numbers %v at scale %v, got %v", row.expectedBN, row.r, bn)
+		}
+		for idx, ebn := range row.expectedBN {
+			if bn[idx] != ebn {
+				t.Errorf("expected Betti numbers %v at scale %v, got %v", row.expectedBN, row.r, bn)
+				break
+			}
+		}
+	}
+
+	// The alpha filtration has the circle's hole living from the sides to the middle
+	c, _ := DelaunayComplex(points)
+	f := c.Filtration(func(s *Simplex) float64 { return s.Data.(float64) })
+	b1 := f.Barcode(1)
+	if len(b1) != 1 || b1[0].Birth > 0.3 || b1[0].Death < 0.9 {
+		t.Errorf("expected one long interval in dimension 1, got %v", b1)
+	}
+}
+
+// simplexVolume returns the volume of the full dimensional simplex spanned by the points.
+func simplexVolume(points [][]float64) float64 {
+	dim := len(points[0])
+	m := make([][]float64, dim)
+	for i := range m {
+		m[i] = make([]float64, dim)
+		for j := range m[i] {
+			m[i][j] = points[i+1][j] - points[0][j]
+		}
+	}
+
+	// Determinant by Gaussian elimination
+	det := 1.0
+	for col := 0; col < dim; col++ {
+		pivot := col
+		for row := col + 1; row < dim; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if m[pivot][col] == 0 {
+			return 0
+		}
+		if pivot != col {
+			m[pivot], m[col] = m[col], m[pivot]
+			det = -det
+		}
+		det *= m[col][col]
+		for row := col + 1; row < dim; row++ {
+			q := m[row][col] / m[col][col]
+			for k := col; k < dim; k++ {
+				m[row][k] -= q * m[col][k]
+			}
+		}
+	}
+
+	volume := math.Abs(det)
+	for k := 2; k <= dim; k++ {
+		volume /= float64(k)
+	}
+
+	return volume
+}
+
+// hullArea returns the area of the convex hull of points in the plane, using the monotone chain algorithm.
+func hullArea(points [][]float64) float64 {
+	ps := append([][]float64(nil), points...)
+	sort.Slice(ps, func(i, j int) bool {
+		if ps[i][0] != ps[j][0] {
+			return ps[i][0] < ps[j][0]
+		}
+		return ps[i][1] < ps[j][1]
+	})
+
+	cross := func(o, a, b []float64) float64 {
+		return (a[0]-o[0])*(b[1]-o[1]) - (a[1]-o[1])*(b[0]-o[0])
+	}
+
+	hull := [][]float64{}
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for _, p := range ps {
+			for len(hull) >= start+2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		hull = hull[:len(hull)-1]
+
+		for i, j := 0, len(ps)-1; i < j; i, j = i+1, j-1 {
+			ps[i], ps[j] = ps[j], ps[i]
+		}
+	}
+
+	var area float64
+	for i := range hull {
+		a, b := hull[i], hull[(i+1)%len(hull)]
+		area += a[0]*b[1] - a[1]*b[0]
+	}
+
+	return math.Abs(area) / 2
+}
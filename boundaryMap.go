@@ -1,12 +1,25 @@
 package comptop
 
 import (
+	"math/big"
+
 	"gonum.org/v1/gonum/mat"
 )
 
 // BoundaryMap is the bonudary map between chain groups of dimensions p and p-1.
 type BoundaryMap struct {
-	mat mat.Matrix
+	chainGroup *ChainGroup
+
+	mat    mat.Matrix
+	signed mat.Matrix
+	field  Field
+
+	// the boundary matrix over Z_2 and its column reduction
+	d       *gf2Matrix
+	r       *gf2Matrix
+	rv      *gf2Matrix
+	rank    int
+	reduced bool
 
 	sn *mat.Dense
 	u  *mat.Dense
@@ -16,13 +29,55 @@ type BoundaryMap struct {
 	dl  *int
 	zp  *int
 	bpl *int
+
+	zdiag []*big.Int
+
+	// exact factors, used when the field is not Z_2
+	fu  fieldMatrix
+	fui fieldMatrix
+	fv  fieldMatrix
+}
+
+// Field returns the field of coefficients the boundary map is defined over.
+func (bm *BoundaryMap) Field() Field {
+	if bm.field == nil {
+		return Z2
+	}
+
+	return bm.field
 }
 
 // BoundaryMatrix returns the matrix representation of the boundary map.
 func (bm *BoundaryMap) BoundaryMatrix() mat.Matrix {
+	if bm.mat == nil && bm.d != nil {
+		bm.mat = bm.d.dense()
+	}
+
 	return bm.mat
 }
 
+// SignedBoundaryMatrix returns the matrix representation of the oriented boundary map, with entries +1 and -1.
+func (bm *BoundaryMap) SignedBoundaryMatrix() mat.Matrix {
+	if bm.signed == nil && bm.chainGroup != nil {
+		bm.signed = bm.chainGroup.SignedBoundaryMatrix()
+	}
+
+	if bm.signed == nil {
+		return bm.BoundaryMatrix()
+	}
+
+	return bm.signed
+}
+
+// gf2 returns the boundary matrix over Z_2.
+func (bm *BoundaryMap) gf2() *gf2Matrix {
+	if bm.d == nil {
+		bm.d = gf2MatrixFrom(bm.mat)
+	}
+
+	return bm.d
+}
+
 // SmithNormal returns the Smith normal form of the boundary matrix.
 func (bm *BoundaryMap) SmithNormal() mat.Matrix {
 	if bm.sn != nil {
@@ -35,37 +90,53 @@ func (bm *BoundaryMap) SmithNormal() mat.Matrix {
 }
 
 // U returns the the left-side matrix in the Smith normal factorization of the boundaty matrix.
+// Over Z_2, U is only formed on the first call, by inverting the matrix returned by UInverse.
 func (bm *BoundaryMap) U() mat.Matrix {
 	if bm.u == nil {
 		bm.reduce()
 	}
 
+	if bm.u == nil && bm.reduced {
+		bm.u = bm.gf2UInverse().inverse().dense()
+	}
+
 	return bm.u
 }
 
 // UInverse returns the inverse of the left-side matrix in the Smith normal factorization of the boundaty matrix.
 func (bm *BoundaryMap) UInverse() mat.Matrix {
-	if bm.ui != nil {
-		return bm.ui
+	if bm.ui == nil {
+		bm.reduce()
 	}
 
-	u := bm.U()
-	m, n := u.Dims()
-	bm.ui = mat.NewDense(m, n, nil)
-	bm.ui.Inverse(u)
+	if bm.ui == nil && bm.reduced {
+		bm.ui = bm.gf2UInverse().dense()
+	}
 
-	// Map into into M_{n,m}(Z_2)
-	for row := 0; row < m; row++ {
-		for col := 0; col < n; col++ {
-			x := int(bm.ui.At(row, col))
-			x = x % 2
-			x *= x
+	return bm.ui
+}
 
-			bm.ui.Set(row, col, float64(x))
+// gf2UInverse returns the inverse of U over Z_2: the non-zero reduced columns
+// followed by the standard basis vectors for the rows that aren't the lowest 1 of any of them.
+func (bm *BoundaryMap) gf2UInverse() *gf2Matrix {
+	bm.reduceZ2()
+
+	m, _ := bm.dims()
+	ui := newGF2Matrix(m, m)
+	isLow := make([]bool, m)
+	for col := 0; col < bm.rank; col++ {
+		copy(ui.data[col], bm.r.data[col])
+		isLow[bm.r.low(col)] = true
+	}
+	col := bm.rank
+	for row := 0; row < m; row++ {
+		if !isLow[row] {
+			ui.set(row, col)
+			col++
 		}
 	}
 
-	return bm.ui
+	return ui
 }
 
 // V returns the the right-side matrix in the Smith normal factorization of the boundaty matrix.
@@ -83,6 +154,13 @@ func (bm *BoundaryMap) SmithNormalDiagonalLength() int {
 		return *bm.dl
 	}
 
+	if isBinary(bm.field) {
+		bm.reduceZ2()
+		bm.dl = &bm.rank
+
+		return bm.rank
+	}
+
 	if bm.sn == nil {
 		bm.reduce()
 	}
@@ -116,7 +194,7 @@ func (bm *BoundaryMap) Zp() int {
 		return *bm.zp
 	}
 
-	_, n := bm.SmithNormal().Dims()
+	_, n := bm.dims()
 
 	z := n - bm.SmithNormalDiagonalLength()
 	bm.zp = &z
@@ -124,6 +202,15 @@ func (bm *BoundaryMap) Zp() int {
 	return z
 }
 
+// dims returns the number of rows and columns of the boundary matrix without exporting it.
+func (bm *BoundaryMap) dims() (int, int) {
+	if bm.d != nil {
+		return bm.d.rows, bm.d.cols
+	}
+
+	return bm.mat.Dims()
+}
+
 // BpLow returns the number of non-zero rows in the Smith normal form of the boundary matrix.
 // The value returned by BpLow coincides with the rank of the image of the boundary matrix,
 // which is the same as the rank of the boundary group B_{p-1} < Z_{p-1} < C_{p-1}.
@@ -138,163 +225,203 @@ func (bm *BoundaryMap) BpLow() int {
 	return *bm.bpl
 }
 
-// reduce computes the Smith normal form (u and v matrices are also computed along the way).
-func (bm *BoundaryMap) reduce() {
-	if bm.sn != nil || bm.mat == nil {
-		return
-	}
+// kernelBasis returns the columns of V matching the zero columns of the reduced boundary matrix, which span the kernel of bm.
+func (bm *BoundaryMap) kernelBasis() [][]*big.Rat {
+	l := bm.SmithNormalDiagonalLength()
+	basis := [][]*big.Rat{}
 
-	// Create a copy of the boundary matrix that we can mutate into its Smith normal form
-	bm.sn = mat.DenseCopyOf(bm.mat)
+	if isBinary(bm.field) {
+		for i := l; i < bm.rv.cols; i++ {
+			basis = append(basis, bm.rv.column(i))
+		}
 
-	// Create the U and V factors: SmithNormal = U * BoundaryMatrix * V
-	m, n := bm.mat.Dims()
-	bm.u = mat.NewDense(m, m, nil)
-	for i := 0; i < m; i++ {
-		bm.u.Set(i, i, 1.0)
+		return basis
 	}
 
-	bm.v = mat.NewDense(n, n, nil)
-	for i := 0; i < n; i++ {
-		bm.v.Set(i, i, 1.0)
+	bm.reduce()
+	_, n := bm.fv.dims()
+	for i := l; i < n; i++ {
+		basis = append(basis, bm.fv.col(i))
 	}
 
-	// Carry out the Smith normal reduction
-	_reduce(0, bm.sn, bm.u, bm.v)
+	return basis
+}
+
+// imageBasis returns the non-zero columns of the reduced boundary matrix, which span the image of bm.
+func (bm *BoundaryMap) imageBasis() [][]*big.Rat {
+	l := bm.SmithNormalDiagonalLength()
+	basis := [][]*big.Rat{}
 
-	// Map matrices U and V into M(Z_2)
-	for row := 0; row < m; row++ {
-		for col := 0; col < m; col++ {
-			bm.u.Set(row, col,
-				float64(int(bm.u.At(row, col))%2),
-			)
+	if isBinary(bm.field) {
+		for i := 0; i < l; i++ {
+			basis = append(basis, bm.r.column(i))
 		}
+
+		return basis
 	}
 
-	for row := 0; row < n; row++ {
-		for col := 0; col < n; col++ {
-			bm.v.Set(row, col,
-				float64(int(bm.v.At(row, col))%2),
-			)
-		}
+	bm.reduce()
+	for i := 0; i < l; i++ {
+		basis = append(basis, bm.fui.col(i))
 	}
+
+	return basis
 }
 
-// _reduce is the actual reduction algorithm for computhing the Smith normal form of the boundary matrix.
-// Taken from 'Computational Topology: An Introduction' by Edelsbrunner & Harer, pg 88.
-func _reduce(x int, n, u, v *mat.Dense) {
-	uDim, vDim := n.Dims()
+// reduce computes the Smith normal form (the v matrix is also computed along the way, as are u and its inverse over fields other than Z_2).
+func (bm *BoundaryMap) reduce() {
+	if bm.sn != nil || (bm.mat == nil && bm.d == nil) {
+		return
+	}
 
-	var doWork bool
+	if !isBinary(bm.field) {
+		bm.reduceOverField()
+		return
+	}
 
-outer:
-	for rIdx := x; rIdx < uDim; rIdx++ {
-		for cIdx := x; cIdx < vDim; cIdx++ {
-			if n.At(rIdx, cIdx) == 1 {
-				uu := mat.NewDense(uDim, uDim, nil)
-				for i := 0; i < uDim; i++ {
-					uu.Set(i, i, 1.0)
-				}
-				_swapRows(rIdx, x, n, uu)
-				u.Mul(uu, u)
-				vv := mat.NewDense(vDim, vDim, nil)
-				for i := 0; i < vDim; i++ {
-					vv.Set(i, i, 1.0)
-				}
-				_swapCols(cIdx, x, n, vv)
-				v.Mul(v, vv)
+	bm.reduceZ2()
 
-				doWork = true
+	m, n := bm.dims()
 
-				break outer
-			}
-		}
+	// SmithNormal = U * BoundaryMatrix * V; U and its inverse are square in the number of rows, so they are left to U and UInverse
+	bm.sn = mat.NewDense(m, n, nil)
+	for i := 0; i < bm.rank; i++ {
+		bm.sn.Set(i, i, 1.0)
 	}
+	bm.v = bm.rv.dense()
+}
 
-	if !doWork {
+// reduceZ2 reduces the boundary matrix over Z_2 by adding columns, left to right, until no two non-zero columns share their lowest 1.
+// The reduced matrix R = D * V is stored with its non-zero columns first; the columns of V matching the zero columns of R span the kernel,
+// and the non-zero columns of R span the image.
+func (bm *BoundaryMap) reduceZ2() {
+	if bm.reduced {
 		return
 	}
 
-	for i := x + 1; i < uDim; i++ {
-		if n.At(i, x) == 1 {
-			uu := mat.NewDense(uDim, uDim, nil)
-			for i := 0; i < uDim; i++ {
-				uu.Set(i, i, 1.0)
+	r := bm.gf2().copy()
+	v := r.columnReduce()
+
+	// Move the non-zero columns of R (and the matching columns of V) to the front
+	bm.r = newGF2Matrix(r.rows, r.cols)
+	bm.rv = newGF2Matrix(v.rows, v.cols)
+	next := 0
+	for pass := 0; pass < 2; pass++ {
+		for col := 0; col < r.cols; col++ {
+			if (r.low(col) >= 0) != (pass == 0) {
+				continue
 			}
-			_addRows(x, i, n, uu)
-			u.Mul(uu, u)
+
+			bm.r.data[next] = r.data[col]
+			bm.rv.data[next] = v.data[col]
+			next++
 		}
-	}
 
-	for i := x + 1; i < vDim; i++ {
-		if n.At(x, i) == 1 {
-			vv := mat.NewDense(vDim, vDim, nil)
-			for i := 0; i < vDim; i++ {
-				vv.Set(i, i, 1.0)
-			}
-			_addCols(x, i, n, vv)
-			v.Mul(v, vv)
+		if pass == 0 {
+			bm.rank = next
 		}
 	}
 
-	_reduce(x+1, n, u, v)
+	bm.reduced = true
 }
 
-// Below are the matrix operations layed out in
-// 'Computational Topology: An Introduction' by Edelsbrunner & Harer, pgs 86-87.
+// reduceOverField computes the Smith normal form of the oriented boundary matrix over a field other than Z_2.
+// Over a field every non-zero pivot is a unit, so the Smith normal form has only 1s and 0s on its diagonal.
+// The exact factors are kept alongside their float64 counterparts, as is the inverse of U.
+func (bm *BoundaryMap) reduceOverField() {
+	f := bm.field
 
-func _swapRows(i, j int, a, u *mat.Dense) {
-	if i == j {
-		return
-	}
-
-	iRow := mat.Row(nil, i, a)
-	a.SetRow(i, mat.Row(nil, j, a))
-	a.SetRow(j, iRow)
-
-	u.Set(i, j, 1.0)
-	u.Set(j, i, 1.0)
-	u.Set(i, i, 0.0)
-	u.Set(j, j, 0.0)
-}
+	src := bm.SignedBoundaryMatrix()
 
-func _swapCols(i, j int, a, v *mat.Dense) {
-	if i == j {
-		return
+	m, n := src.Dims()
+	a := newFieldMatrix(m, n)
+	for row := 0; row < m; row++ {
+		for col := 0; col < n; col++ {
+			a[row][col] = f.Reduce(new(big.Rat).SetFloat64(src.At(row, col)))
+		}
 	}
 
-	iCol := mat.Col(nil, i, a)
-	a.SetCol(i, mat.Col(nil, j, a))
-	a.SetCol(j, iCol)
-
-	v.Set(i, j, 1.0)
-	v.Set(j, i, 1.0)
-	v.Set(i, i, 0.0)
-	v.Set(j, j, 0.0)
-}
+	u := newFieldIdentity(m)
+	ui := newFieldIdentity(m)
+	v := newFieldIdentity(n)
+
+	for t := 0; t < m && t < n; t++ {
+		pr, pc := -1, -1
+	search:
+		for col := t; col < n; col++ {
+			for row := t; row < m; row++ {
+				if a[row][col].Sign() != 0 {
+					pr, pc = row, col
+					break search
+				}
+			}
+		}
 
-// _addRows adds row l to row k ( k -> k+l )
-func _addRows(l, k int, a, u *mat.Dense) {
-	_, n := a.Dims()
+		if pr < 0 {
+			break
+		}
 
-	for col := 0; col < n; col++ {
-		a.Set(k, col, float64(int(a.At(k, col)+a.At(l, col))%2))
-	}
+		// Move the pivot into place: swapping rows of A and U swaps columns of U^-1
+		a[t], a[pr] = a[pr], a[t]
+		u[t], u[pr] = u[pr], u[t]
+		for row := 0; row < m; row++ {
+			ui[row][t], ui[row][pr] = ui[row][pr], ui[row][t]
+		}
+		for row := 0; row < m; row++ {
+			a[row][t], a[row][pc] = a[row][pc], a[row][t]
+		}
+		for row := 0; row < n; row++ {
+			v[row][t], v[row][pc] = v[row][pc], v[row][t]
+		}
 
-	u.Set(k, l, 1.0)
-	u.Set(k, k, 1.0)
-	u.Set(l, l, 1.0)
-}
+		// Scale the pivot to 1: scaling row t of U by c scales column t of U^-1 by 1/c
+		p := a[t][t]
+		inv := f.Inverse(p)
+		for col := 0; col < n; col++ {
+			a[t][col] = fieldMul(f, a[t][col], inv)
+		}
+		for col := 0; col < m; col++ {
+			u[t][col] = fieldMul(f, u[t][col], inv)
+		}
+		for row := 0; row < m; row++ {
+			ui[row][t] = fieldMul(f, ui[row][t], p)
+		}
 
-// _addCols adds col k to col l ( l -> k+l )
-func _addCols(k, l int, a, v *mat.Dense) {
-	m, _ := a.Dims()
+		// Clear the pivot's column: subtracting c * row t from row i adds c * column i to column t of U^-1
+		for row := t + 1; row < m; row++ {
+			c := a[row][t]
+			if c.Sign() == 0 {
+				continue
+			}
+			for col := 0; col < n; col++ {
+				a[row][col] = fieldSub(f, a[row][col], fieldMul(f, c, a[t][col]))
+			}
+			for col := 0; col < m; col++ {
+				u[row][col] = fieldSub(f, u[row][col], fieldMul(f, c, u[t][col]))
+			}
+			for r := 0; r < m; r++ {
+				ui[r][t] = fieldAdd(f, ui[r][t], fieldMul(f, c, ui[r][row]))
+			}
+		}
 
-	for row := 0; row < m; row++ {
-		a.Set(row, l, float64(int(a.At(row, l)+a.At(row, k))%2))
+		// Clear the pivot's row
+		for col := t + 1; col < n; col++ {
+			c := a[t][col]
+			if c.Sign() == 0 {
+				continue
+			}
+			for row := 0; row < m; row++ {
+				a[row][col] = fieldSub(f, a[row][col], fieldMul(f, c, a[row][t]))
+			}
+			for row := 0; row < n; row++ {
+				v[row][col] = fieldSub(f, v[row][col], fieldMul(f, c, v[row][t]))
+			}
+		}
 	}
 
-	v.Set(k, l, 1.0)
-	v.Set(k, k, 1.0)
-	v.Set(l, l, 1.0)
+	bm.fu, bm.fui, bm.fv = u, ui, v
+	bm.sn = a.dense()
+	bm.u = u.dense()
+	bm.ui = ui.dense()
+	bm.v = v.dense()
 }
@@ -1,6 +1,7 @@
 package comptop
 
 import (
+	"math"
 	"testing"
 
 	"gonum.org/v1/gonum/mat"
@@ -27,7 +28,7 @@ func TestBoundaryMap_reduce(t *testing.T) {
 
 	a := mat.NewDense(1, 4, nil)
 	a.Mul(bm.mat, bm.v)
-	a.Mul(bm.u, a)
+	a.Mul(bm.U(), a)
 
 	for row := 0; row < 1; row++ {
 		for col := 0; col < 4; col++ {
@@ -65,7 +66,7 @@ func TestBoundaryMap_reduce(t *testing.T) {
 
 	a = mat.NewDense(4, 6, nil)
 	a.Mul(bm.mat, bm.v)
-	a.Mul(bm.u, a)
+	a.Mul(bm.U(), a)
 
 	for row := 0; row < 4; row++ {
 		for col := 0; col < 6; col++ {
@@ -107,7 +108,7 @@ func TestBoundaryMap_reduce(t *testing.T) {
 
 	a = mat.NewDense(6, 4, nil)
 	a.Mul(bm.mat, bm.v)
-	a.Mul(bm.u, a)
+	a.Mul(bm.U(), a)
 
 	for row := 0; row < 6; row++ {
 		for col := 0; col < 4; col++ {
@@ -139,7 +140,7 @@ func TestBoundaryMap_reduce(t *testing.T) {
 
 	a = mat.NewDense(4, 1, nil)
 	a.Mul(bm.mat, bm.v)
-	a.Mul(bm.u, a)
+	a.Mul(bm.U(), a)
 
 	for row := 0; row < 4; row++ {
 		for col := 0; col < 1; col++ {
@@ -151,3 +152,72 @@ func TestBoundaryMap_reduce(t *testing.T) {
 		t.Error("invalid Smith-normal factorization of D_3")
 	}
 }
+
+func TestBoundaryMap_SmithNormalFactorization(t *testing.T) {
+	torus := []Base{
+		{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5},
+		{3, 4, 6}, {4, 6, 7}, {4, 5, 7}, {5, 7, 8}, {3, 5, 8}, {3, 6, 8},
+		{0, 6, 7}, {0, 1, 7}, {1, 7, 8}, {1, 2, 8}, {2, 6, 8}, {0, 2, 6},
+	}
+
+	for _, f := range []Field{Z2, Q} {
+		t.Run(f.String(), func(tt *testing.T) {
+			c := &Complex{}
+			c.SetField(f)
+			c.NewSimplices(torus...)
+
+			for d := Dim(1); d <= 2; d++ {
+				bm := c.ChainGroup(d).BoundaryMap()
+
+				var a, ui mat.Dense
+				a.Mul(bm.U(), bm.SignedBoundaryMatrix())
+				a.Mul(&a, bm.V())
+				ui.Mul(bm.U(), bm.UInverse())
+
+				if isBinary(f) {
+					mod2(&a)
+					mod2(&ui)
+				}
+
+				if !mat.EqualApprox(&a, bm.SmithNormal(), 1e-9) {
+					tt.Errorf("expected U * D_%d * V to be the Smith normal form", d)
+				}
+				if !isIdentity(&ui) {
+					tt.Errorf("expected U * U^-1 to be the identity for D_%d", d)
+				}
+			}
+		})
+	}
+}
+
+// mod2 reduces the integer entries of a modulo 2.
+func mod2(a *mat.Dense) {
+	m, n := a.Dims()
+	for row := 0; row < m; row++ {
+		for col := 0; col < n; col++ {
+			a.Set(row, col, float64(int(math.Abs(a.At(row, col)))%2))
+		}
+	}
+}
+
+// isIdentity returns true if a is a square identity matrix up to rounding.
+func isIdentity(a mat.Matrix) bool {
+	m, n := a.Dims()
+	if m != n {
+		return false
+	}
+
+	for row := 0; row < m; row++ {
+		for col := 0; col < n; col++ {
+			x := a.At(row, col)
+			if row == col {
+				x--
+			}
+			if math.Abs(x) > 1e-9 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,204 @@
+package comptop
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// CechComplex returns the Čech complex of the points in Euclidean space at scale r.
+// The i^th point becomes the 0-simplex with Index i and a set of points spans a Simplex if the balls of radius r around them have a common point,
+// which happens exactly when their minimal enclosing ball has a radius of at most r.
+// Simplices of dimension larger than maxDim are not constructed.
+// The Data field of every Simplex holds the radius of the minimal enclosing ball of its vertices as a float64;
+// filtering by it gives the Čech filtration.
+//
+// Every Simplex of the Čech complex at scale r is in the Vietoris-Rips complex at scale 2r, whose cliques are used as candidates.
+//
+// More info: https://en.wikipedia.org/wiki/%C4%8Cech_complex
+func CechComplex(points [][]float64, r float64, maxDim Dim) *Complex {
+	c := &Complex{}
+	if len(points) == 0 {
+		return c
+	}
+
+	d := euclideanDistances(points)
+	n := len(points)
+
+	neighbors := make([]Base, n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if d.At(i, j) <= 2*r {
+				neighbors[i] = append(neighbors[i], Index(j))
+			}
+		}
+	}
+
+	radii := map[string]float64{}
+
+	simplices := []Base{}
+	for i := 0; i < n; i++ {
+		simplices = append(simplices, Base{Index(i)})
+	}
+
+	// Grow each simplex by the common upper neighbors of its vertices, keeping those whose enclosing ball is small enough;
+	// faces of a kept simplex have smaller enclosing balls, so they are kept as well.
+	frontier := simplices
+	for dim := Dim(1); dim <= maxDim && len(frontier) > 0; dim++ {
+		next := []Base{}
+		for _, b := range frontier {
+			last := b[len(b)-1]
+			for _, v := range neighbors[last] {
+				if !isNeighborOfAll(neighbors, b, v) {
+					continue
+				}
+
+				candidate := make(Base, len(b)+1)
+				copy(candidate, b)
+				candidate[len(b)] = v
+
+				_, radius := MinimalEnclosingBall(pointsOf(points, candidate))
+				if radius > r {
+					continue
+				}
+
+				radii[(&simplex{base: candidate}).key()] = radius
+				next = append(next, candidate)
+			}
+		}
+
+		simplices = append(simplices, next...)
+		frontier = next
+	}
+
+	c.NewSimplices(simplices...)
+
+	for dim := Dim(0); dim <= c.dim; dim++ {
+		for _, smplx := range c.GetdSimplices(dim) {
+			smplx.Data = radii[smplx.key()]
+		}
+	}
+
+	return c
+}
+
+// MinimalEnclosingBall returns the center and radius of the smallest ball containing the points.
+// The ball is the circumscribed ball, within their affine hull, of some affinely independent subset of the points;
+// every subset is tried, so this is only meant for the handful of points spanning a Simplex.
+//
+// More info: https://en.wikipedia.org/wiki/Smallest-circle_problem
+func MinimalEnclosingBall(points [][]float64) ([]float64, float64) {
+	if len(points) == 0 {
+		return nil, 0
+	}
+
+	bestCenter := append([]float64(nil), points[0]...)
+	bestRadius := math.Inf(1)
+	if len(points) == 1 {
+		return bestCenter, 0
+	}
+
+	subset := make([][]float64, 0, len(points))
+	for mask := 1; mask < 1<<uint(len(points)); mask++ {
+		subset = subset[:0]
+		for idx := range points {
+			if mask&(1<<uint(idx)) != 0 {
+				subset = append(subset, points[idx])
+			}
+		}
+
+		center, radius, ok := circumsphere(subset)
+		if !ok || radius >= bestRadius {
+			continue
+		}
+
+		if encloses(center, radius, points) {
+			bestCenter, bestRadius = center, radius
+		}
+	}
+
+	return bestCenter, bestRadius
+}
+
+// circumsphere returns the center and radius of the smallest sphere through the points, whose center lies in their affine hull.
+// The last return value is false if the points are not affinely independent.
+func circumsphere(points [][]float64) ([]float64, float64, bool) {
+	p0 := points[0]
+	k := len(points) - 1
+	if k == 0 {
+		return append([]float64(nil), p0...), 0, true
+	}
+
+	// The center is p0 + sum of l_i * (p_i - p0), where the Gram system G l = b makes it equidistant from every point
+	diffs := make([][]float64, k)
+	for i := range diffs {
+		diffs[i] = make([]float64, len(p0))
+		for j := range p0 {
+			diffs[i][j] = points[i+1][j] - p0[j]
+		}
+	}
+
+	g := mat.NewDense(k, k, nil)
+	b := mat.NewVecDense(k, nil)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			g.Set(i, j, dot(diffs[i], diffs[j]))
+		}
+		b.SetVec(i, dot(diffs[i], diffs[i])/2)
+	}
+
+	var l mat.VecDense
+	if err := l.SolveVec(g, b); err != nil {
+		return nil, 0, false
+	}
+
+	center := append([]float64(nil), p0...)
+	for i := 0; i < k; i++ {
+		for j := range center {
+			center[j] += l.AtVec(i) * diffs[i][j]
+		}
+	}
+
+	return center, math.Sqrt(squaredDistance(center, p0)), true
+}
+
+// encloses returns true if every point is within radius of center, up to rounding.
+func encloses(center []float64, radius float64, points [][]float64) bool {
+	tol := 1e-9 * math.Max(1, radius)
+	for _, p := range points {
+		if math.Sqrt(squaredDistance(center, p)) > radius+tol {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pointsOf returns the points indexed by the vertices of b.
+func pointsOf(points [][]float64, b Base) [][]float64 {
+	ps := make([][]float64, len(b))
+	for idx, v := range b {
+		ps[idx] = points[v]
+	}
+
+	return ps
+}
+
+func dot(a, b []float64) float64 {
+	var sum float64
+	for idx := range a {
+		sum += a[idx] * b[idx]
+	}
+
+	return sum
+}
+
+func squaredDistance(a, b []float64) float64 {
+	var sum float64
+	for idx := range a {
+		x := a[idx] - b[idx]
+		sum += x * x
+	}
+
+	return sum
+}
@@ -0,0 +1,77 @@
+package comptop
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMinimalEnclosingBall(t *testing.T) {
+	table := []struct {
+		name   string
+		points [][]float64
+		center []float64
+		radius float64
+	}{
+		{"point", [][]float64{{1, 2}}, []float64{1, 2}, 0},
+		{"segment", [][]float64{{0, 0}, {2, 0}}, []float64{1, 0}, 1},
+		{"equilateral", [][]float64{{0, 0}, {1, 0}, {0.5, math.Sqrt(3) / 2}}, []float64{0.5, math.Sqrt(3) / 6}, 1 / math.Sqrt(3)},
+		{"obtuse", [][]float64{{0, 0}, {2, 0}, {1, 0.1}}, []float64{1, 0}, 1},
+		{"tetrahedron corner", [][]float64{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}}, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}, math.Sqrt(2.0 / 3)},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(tt *testing.T) {
+			center, radius := MinimalEnclosingBall(row.points)
+			if math.Abs(radius-row.radius) > 1e-9 {
+				tt.Errorf("expected radius %v, got %v", row.radius, radius)
+			}
+			if math.Sqrt(squaredDistance(center, row.center)) > 1e-9 {
+				tt.Errorf("expected center %v, got %v", row.center, center)
+			}
+		})
+	}
+}
+
+func TestCechComplex(t *testing.T) {
+	// The corners of the unit square
+	points := [][]float64{
+		{0, 0}, {1, 0}, {1, 1}, {0, 1},
+	}
+
+	table := []struct {
+		r          float64
+		expectedBN []int
+	}{
+		{0.4, []int{4}},
+		{0.5, []int{1, 1}},
+		{0.75, []int{1, 0, 0, 0}},
+	}
+
+	for _, row := range table {
+		c := CechComplex(points, row.r, 3)
+
+		bn := c.BettiNumbers()
+		if len(bn) != len(row.expectedBN) {
+			t.Fatalf("expected Betti numbers %v at scale %v, got %v", row.expectedBN, row.r, bn)
+		}
+		for idx, ebn := range row.expectedBN {
+			if bn[idx] != ebn {
+				t.Errorf("expected Betti numbers %v at scale %v, got %v", row.expectedBN, row.r, bn)
+				break
+			}
+		}
+	}
+
+	// Unlike the Rips complex at twice the scale, the Čech complex leaves out the triangle of an equilateral triangle with sides 1
+	triangle := [][]float64{{0, 0}, {1, 0}, {0.5, math.Sqrt(3) / 2}}
+	c := CechComplex(triangle, 0.55, 2)
+	if c.GetSimplex(0, 1, 2) != nil {
+		t.Error("expected the triangle to be left out at scale 0.55")
+	}
+	if radius := c.GetSimplex(0, 1).Data.(float64); radius != 0.5 {
+		t.Errorf("expected edge [0 1] to have radius 0.5, got %v", radius)
+	}
+	if RipsComplex(triangle, 1.1, 2).GetSimplex(0, 1, 2) == nil {
+		t.Error("expected the Rips complex to include the triangle")
+	}
+}
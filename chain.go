@@ -1,6 +1,8 @@
 package comptop
 
 import (
+	"fmt"
+	"math/big"
 	"sort"
 
 	"gonum.org/v1/gonum/mat"
@@ -27,8 +29,8 @@ func (c *Chain) Swap(i, j int) {
 }
 
 // Chain is an element of a ChainGroup.
-// Chains are formal sums over the p-dimensional Simplices of a Complex with coefficients in Z_2 = Z/2Z.
-// This means that adding a Chain to itself results in an empty Chain (the zero element of the ChainGroup).
+// Chains are formal sums over the p-dimensional Simplices of a Complex with coefficients in the Field of the Complex.
+// By default the Field is Z_2 = Z/2Z, which means that adding a Chain to itself results in an empty Chain (the zero element of the ChainGroup).
 //
 // More info: https://en.wikipedia.org/wiki/Simplicial_homology#Chains
 type Chain struct {
@@ -41,6 +43,9 @@ type Chain struct {
 	idxs   map[Index]*Simplex
 	dim    Dim
 
+	// coefficients is nil if every simplex in the chain has coefficient 1
+	coefficients map[Index]*big.Rat
+
 	eulerChar *int
 
 	isCycle bool
@@ -55,6 +60,9 @@ func (c *Chain) String() string {
 	s := "Chain{"
 
 	for _, smplx := range c.simplices {
+		if c.coefficients != nil {
+			s += fmt.Sprintf("%v*", c.coefficients[smplx.index].RatString())
+		}
 		s += smplx.String() + ", "
 
 	}
@@ -71,13 +79,44 @@ func (c *Chain) Dim() Dim {
 }
 
 func (c *Chain) ChainGroup() *ChainGroup {
-	return c.ChainGroup()
+	return c.chaingroup
 }
 
 func (c *Chain) IsZero() bool {
 	return len(c.simplices) == 0
 }
 
+// Field returns the field of coefficients of c.
+func (c *Chain) Field() Field {
+	return c.complex.Field()
+}
+
+// Coefficient returns the coefficient of s in c.
+func (c *Chain) Coefficient(s *Simplex) *big.Rat {
+	smplx, inChain := c.idxs[s.index]
+	if !inChain || smplx != s {
+		return new(big.Rat)
+	}
+
+	if c.coefficients == nil {
+		return big.NewRat(1, 1)
+	}
+
+	return new(big.Rat).Set(c.coefficients[s.index])
+}
+
+// Scale returns the Chain obtained by multiplying each coefficient of c by a.
+func (c *Chain) Scale(a *big.Rat) *Chain {
+	f := c.Field()
+
+	coeffs := map[*Simplex]*big.Rat{}
+	for _, smplx := range c.simplices {
+		coeffs[smplx] = fieldMul(f, a, c.Coefficient(smplx))
+	}
+
+	return c.chaingroup.NewChainFromCoefficients(coeffs)
+}
+
 // Add returns the results of adding Chain c to Chain a.
 // Since Chain is an element of a boolean group, if c == a then the resulting Chain is empty.
 func (c *Chain) Add(a *Chain) *Chain {
@@ -93,6 +132,22 @@ func (c *Chain) Add(a *Chain) *Chain {
 		return nil
 	}
 
+	if f := c.Field(); !isBinary(f) {
+		coeffs := map[*Simplex]*big.Rat{}
+		for _, smplx := range c.simplices {
+			coeffs[smplx] = c.Coefficient(smplx)
+		}
+		for _, smplx := range a.simplices {
+			if x, exists := coeffs[smplx]; exists {
+				coeffs[smplx] = fieldAdd(f, x, a.Coefficient(smplx))
+				continue
+			}
+			coeffs[smplx] = a.Coefficient(smplx)
+		}
+
+		return c.chaingroup.NewChainFromCoefficients(coeffs)
+	}
+
 	// Count all of the simplices in the chain both chains
 	simplexCount := map[*Simplex]uint{}
 	for _, smplx := range a.simplices {
@@ -209,8 +264,8 @@ func (c *Chain) Simplices() []*Simplex {
 }
 
 // Vector returns the vector representation of c.
-// A Chain c is represented as a Vector v by assigning v_i = 1 if
-// c contains the i^th simplex in the basis of the ChainGroup; v_i = 0 otherwise.
+// A Chain c is represented as a Vector v by assigning v_i the coefficient of the i^th simplex in the basis of the ChainGroup;
+// over Z_2 this means v_i = 1 if c contains the i^th simplex and v_i = 0 otherwise.
 func (c *Chain) Vector() Vector {
 	if c.vector != nil {
 		v := mat.DenseCopyOf(c.vector)
@@ -224,8 +279,9 @@ func (c *Chain) Vector() Vector {
 	v := c.vector.(*mat.Dense)
 
 	for i := Index(0); i < n; i++ {
-		if _, inChain := c.idxs[i]; inChain {
-			v.Set(int(i), 0, 1.0)
+		if smplx, inChain := c.idxs[i]; inChain {
+			x, _ := c.Coefficient(smplx).Float64()
+			v.Set(int(i), 0, x)
 		}
 	}
 
@@ -233,6 +289,20 @@ func (c *Chain) Vector() Vector {
 	return mat.Matrix(vv).(Vector)
 }
 
+// CoefficientVector returns the coefficient of c on the Simplex with Index i as its i^th entry.
+// Unlike Vector, it holds coefficients exactly over every Field; ChainFromCoefficientVector turns it back into c.
+func (c *Chain) CoefficientVector() []*big.Rat {
+	v := make([]*big.Rat, c.chaingroup.Rank())
+	for idx := range v {
+		v[idx] = new(big.Rat)
+		if smplx, inChain := c.idxs[Index(idx)]; inChain {
+			v[idx] = c.Coefficient(smplx)
+		}
+	}
+
+	return v
+}
+
 // Boundary is a group homomorphism from a p-dimensional ChainGroup to a (p-1)-dimensional ChainGroup.
 // In particular, Boundary returns the Chain of simplices that make up the boundary/faces of c.
 // For example: If c represents an edge, then the boundary is the chain consisting of the 2 vertices that it connects; if c is a filled in triangle, the boundary is the chain of the 3 edges that make up the triangle.
@@ -255,21 +325,25 @@ func (c *Chain) Boundary() *Chain {
 	group := c.chaingroup
 	lowerGroup := complex.ChainGroup(group.dim - 1)
 
-	bm := group.BoundaryMap().BoundaryMatrix()
-	v := c.Vector().(mat.Matrix)
-
-	bmm, _ := bm.Dims()
+	if !isBinary(c.Field()) {
+		boundary := lowerGroup.NewChainFromCoefficients(c.signedFaces())
+		boundary.isCycle = true
 
-	x := mat.NewDense(bmm, 1, nil)
-	x.Mul(bm, v)
-
-	for i := 0; i < bmm; i++ {
-		x.Set(i, 0, float64(int(x.At(i, 0))%2))
+		return boundary
 	}
 
-	xv := mat.Matrix(x).(Vector)
+	// Add up the columns of the boundary matrix belonging to the simplices in c
+	d := group.BoundaryMap().gf2()
+	x := newGF2Matrix(d.rows, 1)
+	group.sortIdxs()
+	for _, smplx := range c.simplices {
+		col := sort.Search(len(group.idxs), func(j int) bool {
+			return group.idxs[j] >= smplx.index
+		})
+		x.addColFrom(d, col, 0)
+	}
 
-	boundary := lowerGroup.ChainFromVector(xv)
+	boundary := lowerGroup.chainFromGF2Column(x, 0)
 	boundary.isCycle = true
 
 	return boundary
@@ -294,6 +368,10 @@ func (c *Chain) Equals(a *Chain) bool {
 		if !cs.Equals(as) {
 			return false
 		}
+
+		if c.Coefficient(cs).Cmp(a.Coefficient(as)) != 0 {
+			return false
+		}
 	}
 
 	return true
@@ -2,6 +2,7 @@ package comptop
 
 import (
 	"fmt"
+	"math/big"
 	"sort"
 
 	"gonum.org/v1/gonum/mat"
@@ -23,15 +24,24 @@ type ChainGroup struct {
 	basespace  map[Index]struct{}
 	dim        Dim
 
+	// synced is true once idxs and basespace hold the simplices stored in the SimplexTree backing the Complex, if there is one
+	synced bool
+
 	zero *Chain
 
 	head Index
 
 	bm *BoundaryMap
 
-	cg *CycleGroup
-	bg *BoundaryGroup
-	hg *HomologyGroup
+	cg  *CycleGroup
+	bg  *BoundaryGroup
+	hg  *HomologyGroup
+	ihg *IntegralHomologyGroup
+
+	cbm *BoundaryMap
+	ccg *CocycleGroup
+	cbg *CoboundaryGroup
+	chg *CohomologyGroup
 }
 
 func (c *Complex) newChainGroup(dim Dim) *ChainGroup {
@@ -68,6 +78,9 @@ func (cg *ChainGroup) addSimplex(s *Simplex) {
 	}
 
 	cg.simplices[s.index] = s
+	if cg.complex.tree == nil {
+		cg.complex.register(s)
+	}
 	for _, v := range s.base {
 		cg.basespace[v] = struct{}{}
 	}
@@ -75,11 +88,33 @@ func (cg *ChainGroup) addSimplex(s *Simplex) {
 
 	cg.idxsSorted = false
 	cg.bm = nil
+	cg.ihg = nil
+	cg.resetCohomology()
 
 	higherGroup := cg.complex.chainGroups[cg.dim+1]
 	if higherGroup != nil {
 		higherGroup.bm = nil
+		higherGroup.resetCohomology()
 	}
+
+	if cg.dim > 0 {
+		if lowerGroup := cg.complex.chainGroups[cg.dim-1]; lowerGroup != nil {
+			lowerGroup.ihg = nil
+			lowerGroup.resetCohomology()
+		}
+	}
+}
+
+func (cg *ChainGroup) resetCohomology() {
+	cg.cbm = nil
+	cg.ccg = nil
+	cg.cbg = nil
+	cg.chg = nil
+}
+
+// Field returns the field of coefficients for the chains in the ChainGroup.
+func (cg *ChainGroup) Field() Field {
+	return cg.complex.Field()
 }
 
 // Dim is the dimension of the simplices that make up the ChainGroup.
@@ -87,8 +122,33 @@ func (cg *ChainGroup) Dim() Dim {
 	return cg.dim
 }
 
+// sync reads the basis of cg from the SimplexTree backing its Complex, without creating any Simplex.
+func (cg *ChainGroup) sync() {
+	tree := cg.complex.tree
+	if tree == nil || cg.synced {
+		return
+	}
+
+	cg.idxs = tree.indices(cg.dim)
+	cg.idxsSorted = false
+
+	cg.basespace = map[Index]struct{}{}
+	for _, idx := range cg.idxs {
+		for n := tree.node(cg.dim, idx); n.parent != nil; n = n.parent {
+			cg.basespace[n.label] = struct{}{}
+		}
+	}
+
+	cg.synced = true
+}
+
 // Rank is the number of simplices that make up the ChainGroup.
 func (cg *ChainGroup) Rank() int {
+	if cg.complex.tree != nil {
+		cg.sync()
+		return len(cg.idxs)
+	}
+
 	return len(cg.simplices)
 }
 
@@ -96,6 +156,15 @@ func (cg *ChainGroup) Rank() int {
 func (cg *ChainGroup) Simplices() []*Simplex {
 	els := []*Simplex{}
 
+	if cg.complex.tree != nil {
+		cg.sortIdxs()
+		for _, idx := range cg.idxs {
+			els = append(els, cg.Simplex(idx))
+		}
+
+		return els
+	}
+
 	if cg.simplices == nil {
 		cg.simplices = map[Index]*Simplex{}
 	}
@@ -109,7 +178,16 @@ func (cg *ChainGroup) Simplices() []*Simplex {
 
 // Simplex returns the Simplex with index idx from the ChainGroups set of simplices.
 func (cg *ChainGroup) Simplex(idx Index) *Simplex {
-	return cg.simplices[idx]
+	if s, exists := cg.simplices[idx]; exists || cg.complex.tree == nil {
+		return s
+	}
+
+	n := cg.complex.tree.node(cg.dim, idx)
+	if n == nil {
+		return nil
+	}
+
+	return cg.complex.materialize(n)
 }
 
 func (cg *ChainGroup) String() string {
@@ -130,6 +208,7 @@ func (cg *ChainGroup) IsElement(c *Chain) bool {
 		return false
 	}
 
+	cg.sync()
 	for v := range c.base {
 		if _, exists := cg.basespace[v]; !exists {
 			return false
@@ -247,6 +326,8 @@ func (cg *ChainGroup) Zero() *Chain {
 }
 
 // ChainFromVector returns the Chain represented by v.
+// Entries of v are taken as the exact rationals they hold as float64s;
+// over a Field other than Z_2, use ChainFromCoefficientVector for coefficients such as 1/3 that a float64 cannot hold.
 func (cg *ChainGroup) ChainFromVector(v Vector) *Chain {
 	if r, _ := v.Dims(); r != cg.Rank() {
 		return nil
@@ -266,10 +347,19 @@ func (cg *ChainGroup) ChainFromVector(v Vector) *Chain {
 	vv := v.(mat.Matrix)
 	vm, _ := vv.Dims()
 
+	if !isBinary(cg.Field()) {
+		coeffs := make([]*big.Rat, vm)
+		for idx := range coeffs {
+			coeffs[idx] = new(big.Rat).SetFloat64(vv.At(idx, 0))
+		}
+
+		return cg.ChainFromCoefficientVector(coeffs)
+	}
+
 	for idx := 0; idx < vm; idx++ {
 		if vv.At(idx, 0) == 1.0 {
 			i := Index(idx)
-			smplx := cg.simplices[i]
+			smplx := cg.Simplex(i)
 			chain.simplices = append(chain.simplices, smplx)
 			chain.idxs[i] = smplx
 			for _, vert := range smplx.base {
@@ -308,6 +398,99 @@ func (cg *ChainGroup) NewChainFromSimplices(s ...*Simplex) *Chain {
 	return chain
 }
 
+// NewChainFromCoefficients returns the Chain whose coefficient on each Simplex is given by coeffs.
+// Coefficients are reduced into the Field of the ChainGroup and simplices with a zero coefficient are left out.
+func (cg *ChainGroup) NewChainFromCoefficients(coeffs map[*Simplex]*big.Rat) *Chain {
+	f := cg.Field()
+
+	chain := &Chain{
+		chain: chain{
+			simplices: []*Simplex{},
+		},
+		complex:    cg.complex,
+		chaingroup: cg,
+		dim:        cg.dim,
+		idxs:       map[Index]*Simplex{},
+		base:       map[Index]struct{}{},
+	}
+
+	if !isBinary(f) {
+		chain.coefficients = map[Index]*big.Rat{}
+	}
+
+	for smplx, x := range coeffs {
+		if smplx.Dim() != chain.dim {
+			continue
+		}
+
+		x = f.Reduce(x)
+		if x.Sign() == 0 {
+			continue
+		}
+
+		chain.simplices = append(chain.simplices, smplx)
+		chain.idxs[smplx.index] = smplx
+		if chain.coefficients != nil {
+			chain.coefficients[smplx.index] = x
+		}
+		for _, vert := range smplx.base {
+			chain.base[vert] = struct{}{}
+		}
+	}
+
+	if len(chain.simplices) == 0 {
+		return cg.zero
+	}
+
+	return chain
+}
+
+// ChainFromCoefficientVector returns the Chain whose coefficient on the Simplex with Index i is v[i], reduced into the Field of the ChainGroup.
+// It is the exact counterpart of ChainFromVector and returns nil if v does not have one entry per Simplex.
+func (cg *ChainGroup) ChainFromCoefficientVector(v []*big.Rat) *Chain {
+	if len(v) != cg.Rank() {
+		return nil
+	}
+
+	coeffs := map[*Simplex]*big.Rat{}
+	for idx, x := range v {
+		if x != nil && x.Sign() != 0 {
+			coeffs[cg.Simplex(Index(idx))] = x
+		}
+	}
+
+	return cg.NewChainFromCoefficients(coeffs)
+}
+
+// chainFromColumn returns the Chain whose coefficients are the entries of col, in the order of the basis of the ChainGroup.
+func (cg *ChainGroup) chainFromColumn(col []*big.Rat) *Chain {
+	cg.sortIdxs()
+
+	coeffs := map[*Simplex]*big.Rat{}
+	for row, x := range col {
+		if x.Sign() != 0 {
+			coeffs[cg.Simplex(cg.idxs[row])] = x
+		}
+	}
+
+	return cg.NewChainFromCoefficients(coeffs)
+}
+
+// column returns the coefficients of c, in the order of the basis of the ChainGroup.
+func (cg *ChainGroup) column(c *Chain) []*big.Rat {
+	cg.sortIdxs()
+
+	col := make([]*big.Rat, len(cg.idxs))
+	for row, idx := range cg.idxs {
+		col[row] = new(big.Rat)
+		if smplx, inChain := c.idxs[idx]; inChain {
+			col[row] = c.Coefficient(smplx)
+		}
+	}
+
+	return col
+}
+
 func (cg *ChainGroup) BoundaryMap() *BoundaryMap {
 	if cg.bm != nil {
 		return cg.bm
@@ -325,54 +508,146 @@ func (cg *ChainGroup) updateBoundaryMap() {
 			data = append(data, 1)
 		}
 
+		bm := mat.NewDense(1, cg.Rank(), data)
 		cg.bm = &BoundaryMap{
-			mat: mat.NewDense(1, cg.Rank(), data),
+			chainGroup: cg,
+			mat:        bm,
+			signed:     bm,
+			field:      cg.complex.field,
 		}
 
 		return
 	}
 
-	cg.sortIdxs()
+	lowerGroup := cg.lowerGroup()
 
-	complex := cg.complex
-	lowerGroup := complex.chainGroups[cg.dim-1]
-	if lowerGroup == nil {
-		complex.chainGroups[cg.dim-1] = cg.complex.newChainGroup(cg.dim - 1)
-		lowerGroup = complex.chainGroups[cg.dim-1]
+	n := cg.Rank()         // cols
+	m := lowerGroup.Rank() // rows
+
+	if m == 0 || n == 0 {
+		return
 	}
-	lowerGroup.sortIdxs()
+
+	d := newGF2Matrix(m, n)
+	cg.boundaryEntries(func(row, col, sign int) {
+		d.set(row, col)
+	})
+
+	cg.bm = &BoundaryMap{
+		chainGroup: cg,
+		d:          d,
+		field:      cg.complex.field,
+	}
+}
+
+// SignedBoundaryMatrix returns the matrix of the oriented boundary map from cg to the ChainGroup one dimension lower.
+// Every simplex is oriented by the increasing order of its vertices, so the entry for a face obtained by deleting the j^th vertex is (-1)^j.
+// Returns nil if cg is 0-dimensional or either group is empty.
+//
+// More info: https://en.wikipedia.org/wiki/Simplicial_homology#Boundaries_and_cycles
+func (cg *ChainGroup) SignedBoundaryMatrix() mat.Matrix {
+	if cg.dim == 0 {
+		return nil
+	}
+
+	lowerGroup := cg.lowerGroup()
 
 	n := cg.Rank()         // cols
 	m := lowerGroup.Rank() // rows
 
 	if m == 0 || n == 0 {
+		return nil
+	}
+
+	sbm := mat.NewDense(m, n, nil)
+	cg.boundaryEntries(func(row, col, sign int) {
+		sbm.Set(row, col, float64(sign))
+	})
+
+	return sbm
+}
+
+func (cg *ChainGroup) lowerGroup() *ChainGroup {
+	return cg.complex.chaingroup(cg.dim - 1)
+}
+
+// boundaryEntries calls f with the row, column and sign of each non-zero entry of the oriented boundary matrix of cg.
+// Rows and columns follow the sorted indices of the lower ChainGroup and cg respectively.
+func (cg *ChainGroup) boundaryEntries(f func(row, col, sign int)) {
+	cg.sortIdxs()
+
+	lowerGroup := cg.lowerGroup()
+	lowerGroup.sortIdxs()
+
+	// Complexes built on a SimplexTree find the rows of faces through the nodes of the tree
+	if tree := cg.complex.tree; tree != nil {
+		rows := make(map[Index]int, len(lowerGroup.idxs))
+		for row, idx := range lowerGroup.idxs {
+			rows[idx] = row
+		}
+
+		for col, idx := range cg.idxs {
+			b := tree.node(cg.dim, idx).base()
+			face := make(Base, len(b)-1)
+			for j := range b {
+				copy(face[:j], b[:j])
+				copy(face[j:], b[j+1:])
+
+				n := tree.find(face)
+				if n == nil {
+					continue
+				}
+
+				sign := 1
+				if j%2 == 1 {
+					sign = -1
+				}
+
+				f(rows[n.index], col, sign)
+			}
+		}
+
 		return
 	}
 
-	bm := mat.NewDense(m, n, make([]float64, n*m))
+	rows := make(map[string]int, len(lowerGroup.idxs))
+	for row, idx := range lowerGroup.idxs {
+		rows[lowerGroup.simplices[idx].key()] = row
+	}
 
-	for row := 0; row < m; row++ {
-		for col := 0; col < n; col++ {
-			rowIdx := lowerGroup.idxs[row]
-			colIdx := cg.idxs[col]
-			lowDimSimplex := lowerGroup.simplices[rowIdx]
-			highDimSimplex := cg.simplices[colIdx]
+	for col, idx := range cg.idxs {
+		smplx := cg.simplices[idx]
+		for j, face := range smplx.d() {
+			row, exists := rows[face.key()]
+			if !exists {
+				continue
+			}
 
-			a := 0.0
-			if highDimSimplex.HasFace(lowDimSimplex) {
-				a = 1.0
+			sign := 1
+			if j%2 == 1 {
+				sign = -1
 			}
 
-			bm.Set(row, col, a)
+			f(row, col, sign)
 		}
 	}
+}
 
-	cg.bm = &BoundaryMap{
-		mat: bm,
+// chainFromGF2Column returns the Chain whose simplices are the rows with a 1 in column col of a,
+// in the order of the basis of the ChainGroup.
+func (cg *ChainGroup) chainFromGF2Column(a *gf2Matrix, col int) *Chain {
+	cg.sortIdxs()
+
+	simplices := []*Simplex{}
+	for _, row := range a.ones(col) {
+		simplices = append(simplices, cg.Simplex(cg.idxs[row]))
 	}
+
+	return cg.NewChainFromSimplices(simplices...)
 }
 
 func (cg *ChainGroup) sortIdxs() {
+	cg.sync()
 	if cg.idxsSorted {
 		return
 	}
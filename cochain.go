@@ -0,0 +1,315 @@
+package comptop
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// Cochain is an element of the cochain group C^p, the dual of the ChainGroup C_p.
+// A Cochain assigns a value in the Field of the Complex to each p-dimensional Simplex and extends linearly to chains.
+// The dual basis of C^p is made up of the cochains which are 1 on a single Simplex and 0 on every other Simplex.
+//
+// More info: https://en.wikipedia.org/wiki/Simplicial_homology#Simplicial_cohomology
+type Cochain struct {
+	chainGroup *ChainGroup
+
+	// values holds the non-zero values of the Cochain
+	values map[*Simplex]*big.Rat
+}
+
+// NewCochain returns the Cochain whose value on each Simplex is given by values.
+// Values are reduced into the Field of the ChainGroup; simplices not in values, or of the wrong dimension, have value 0.
+func (cg *ChainGroup) NewCochain(values map[*Simplex]*big.Rat) *Cochain {
+	f := cg.Field()
+
+	cc := &Cochain{
+		chainGroup: cg,
+		values:     map[*Simplex]*big.Rat{},
+	}
+
+	for smplx, x := range values {
+		if smplx.Dim() != cg.dim {
+			continue
+		}
+
+		if x = f.Reduce(x); x.Sign() != 0 {
+			cc.values[smplx] = x
+		}
+	}
+
+	return cc
+}
+
+// NewCochainFromSimplices returns the Cochain which is 1 on each of the given simplices and 0 everywhere else.
+func (cg *ChainGroup) NewCochainFromSimplices(s ...*Simplex) *Cochain {
+	values := map[*Simplex]*big.Rat{}
+	for _, smplx := range s {
+		values[smplx] = big.NewRat(1, 1)
+	}
+
+	return cg.NewCochain(values)
+}
+
+// cochainFromColumn returns the Cochain whose values, in the order of the basis of the ChainGroup, are given by col.
+func (cg *ChainGroup) cochainFromColumn(col []*big.Rat) *Cochain {
+	cg.sortIdxs()
+
+	values := map[*Simplex]*big.Rat{}
+	for row, x := range col {
+		if x.Sign() != 0 {
+			values[cg.Simplex(cg.idxs[row])] = x
+		}
+	}
+
+	return cg.NewCochain(values)
+}
+
+func (cg *ChainGroup) cochainFromGF2Column(a *gf2Matrix, col int) *Cochain {
+	cg.sortIdxs()
+
+	simplices := []*Simplex{}
+	for _, row := range a.ones(col) {
+		simplices = append(simplices, cg.Simplex(cg.idxs[row]))
+	}
+
+	return cg.NewCochainFromSimplices(simplices...)
+}
+
+// column returns the values of cc, in the order of the basis of its ChainGroup.
+func (cc *Cochain) column() []*big.Rat {
+	cg := cc.chainGroup
+	cg.sortIdxs()
+
+	values := make(map[Index]*big.Rat, len(cc.values))
+	for smplx, x := range cc.values {
+		values[smplx.index] = x
+	}
+
+	col := make([]*big.Rat, len(cg.idxs))
+	for row, idx := range cg.idxs {
+		col[row] = new(big.Rat)
+		if x, exists := values[idx]; exists {
+			col[row].Set(x)
+		}
+	}
+
+	return col
+}
+
+func (cc *Cochain) String() string {
+	s := "Cochain{"
+
+	for _, smplx := range cc.Support() {
+		if !isBinary(cc.Field()) {
+			s += fmt.Sprintf("%v*", cc.values[smplx].RatString())
+		}
+		s += smplx.String() + ", "
+	}
+
+	s += "}"
+
+	return s
+}
+
+// Dim returns the dimension of the simplices cc is defined on.
+func (cc *Cochain) Dim() Dim {
+	return cc.chainGroup.dim
+}
+
+// ChainGroup returns the ChainGroup that cc is dual to.
+func (cc *Cochain) ChainGroup() *ChainGroup {
+	return cc.chainGroup
+}
+
+// Field returns the field of the values of cc.
+func (cc *Cochain) Field() Field {
+	return cc.chainGroup.Field()
+}
+
+// IsZero returns true if cc is 0 on every Simplex.
+func (cc *Cochain) IsZero() bool {
+	return len(cc.values) == 0
+}
+
+// Value returns the value of cc on the Simplex s.
+func (cc *Cochain) Value(s *Simplex) *big.Rat {
+	x, exists := cc.values[s]
+	if !exists {
+		return new(big.Rat)
+	}
+
+	return new(big.Rat).Set(x)
+}
+
+// Support returns the simplices on which cc is not 0, ordered by Index.
+func (cc *Cochain) Support() []*Simplex {
+	simplices := make([]*Simplex, 0, len(cc.values))
+	for smplx := range cc.values {
+		simplices = append(simplices, smplx)
+	}
+	sort.Slice(simplices, func(i, j int) bool {
+		return simplices[i].index < simplices[j].index
+	})
+
+	return simplices
+}
+
+// Evaluate returns the value of cc on the Chain c: the sum over the simplices of c of their coefficient times their value under cc.
+func (cc *Cochain) Evaluate(c *Chain) *big.Rat {
+	f := cc.Field()
+
+	sum := new(big.Rat)
+	if c == nil || c.dim != cc.Dim() {
+		return sum
+	}
+
+	for _, smplx := range c.simplices {
+		sum = fieldAdd(f, sum, fieldMul(f, c.Coefficient(smplx), cc.Value(smplx)))
+	}
+
+	return sum
+}
+
+// Add returns the sum of cc and a; returns nil if they are not dual to the same ChainGroup.
+func (cc *Cochain) Add(a *Cochain) *Cochain {
+	if a == nil {
+		return cc
+	}
+
+	if cc.chainGroup != a.chainGroup {
+		return nil
+	}
+
+	f := cc.Field()
+	values := map[*Simplex]*big.Rat{}
+	for smplx, x := range cc.values {
+		values[smplx] = x
+	}
+	for smplx, x := range a.values {
+		if y, exists := values[smplx]; exists {
+			values[smplx] = fieldAdd(f, x, y)
+			continue
+		}
+		values[smplx] = x
+	}
+
+	return cc.chainGroup.NewCochain(values)
+}
+
+// Scale returns the Cochain obtained by multiplying each value of cc by a.
+func (cc *Cochain) Scale(a *big.Rat) *Cochain {
+	f := cc.Field()
+
+	values := map[*Simplex]*big.Rat{}
+	for smplx, x := range cc.values {
+		values[smplx] = fieldMul(f, a, x)
+	}
+
+	return cc.chainGroup.NewCochain(values)
+}
+
+// Equals returns true if cc and a are dual to the same ChainGroup and agree on every Simplex.
+func (cc *Cochain) Equals(a *Cochain) bool {
+	if a == nil || cc.chainGroup != a.chainGroup || len(cc.values) != len(a.values) {
+		return false
+	}
+
+	for smplx, x := range cc.values {
+		if y, exists := a.values[smplx]; !exists || x.Cmp(y) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Coboundary returns the Cochain δcc of dimension p+1, defined by δcc(σ) = cc(∂σ).
+// The coboundary map is the transpose of the boundary map from dimension p+1 to dimension p.
+// Coboundary returns nil if there are no simplices of dimension p+1.
+//
+// More info: https://en.wikipedia.org/wiki/Simplicial_homology#Simplicial_cohomology
+func (cc *Cochain) Coboundary() *Cochain {
+	cg := cc.chainGroup
+	higherGroup := cg.complex.ChainGroup(cg.dim + 1)
+	if higherGroup == nil {
+		return nil
+	}
+
+	cbm := cg.coboundaryMap()
+	if cbm == nil {
+		return higherGroup.NewCochain(nil)
+	}
+
+	f := cc.Field()
+	x := cc.column()
+
+	if isBinary(f) {
+		// Each column of the transposed matrix lists the cofacets of a Simplex
+		d := cbm.gf2()
+		col := newGF2Matrix(d.rows, 1)
+		for j, v := range x {
+			if v.Sign() != 0 {
+				col.addColFrom(d, j, 0)
+			}
+		}
+
+		return higherGroup.cochainFromGF2Column(col, 0)
+	}
+
+	d := cbm.SignedBoundaryMatrix()
+	m, n := d.Dims()
+	y := make([]*big.Rat, m)
+	for row := 0; row < m; row++ {
+		y[row] = new(big.Rat)
+		for col := 0; col < n; col++ {
+			if a := d.At(row, col); a != 0 && x[col].Sign() != 0 {
+				y[row] = fieldAdd(f, y[row], fieldMul(f, big.NewRat(int64(a), 1), x[col]))
+			}
+		}
+	}
+
+	return higherGroup.cochainFromColumn(y)
+}
+
+// IsCocycle returns true if the coboundary of cc is zero.
+func (cc *Cochain) IsCocycle() bool {
+	cb := cc.Coboundary()
+	return cb == nil || cb.IsZero()
+}
+
+// coboundaryMap returns the coboundary map from C^p to C^{p+1}, whose matrix is the transpose of the boundary matrix from C_{p+1} to C_p.
+// Returns nil if the boundary map from C_{p+1} to C_p is empty.
+func (cg *ChainGroup) coboundaryMap() *BoundaryMap {
+	if cg.cbm != nil {
+		return cg.cbm
+	}
+
+	higherGroup := cg.complex.ChainGroup(cg.dim + 1)
+	if higherGroup == nil {
+		return nil
+	}
+
+	bm := higherGroup.BoundaryMap()
+	if bm == nil {
+		return nil
+	}
+
+	if isBinary(bm.field) {
+		cg.cbm = &BoundaryMap{
+			d:     bm.gf2().transpose(),
+			field: bm.field,
+		}
+
+		return cg.cbm
+	}
+
+	t := bm.SignedBoundaryMatrix().T()
+	cg.cbm = &BoundaryMap{
+		mat:    t,
+		signed: t,
+		field:  bm.field,
+	}
+
+	return cg.cbm
+}
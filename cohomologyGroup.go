@@ -0,0 +1,176 @@
+package comptop
+
+import (
+	"math/big"
+)
+
+// CocycleGroup Z^p is the subgroup of the cochain group C^p made up of the cochains with a zero coboundary (ie cocycles).
+type CocycleGroup struct {
+	chainGroup *ChainGroup
+
+	basis []*Cochain
+}
+
+// CocycleGroup returns the group of p-dimensional cocycles, which is the kernel of the coboundary map from C^p to C^{p+1}.
+func (cg *ChainGroup) CocycleGroup() *CocycleGroup {
+	if cg.ccg != nil {
+		return cg.ccg
+	}
+
+	cg.ccg = &CocycleGroup{
+		chainGroup: cg,
+		basis:      []*Cochain{},
+	}
+
+	cbm := cg.coboundaryMap()
+	if cbm == nil {
+		// Without (p+1)-simplices every cochain is a cocycle
+		cg.sortIdxs()
+		for _, idx := range cg.idxs {
+			cg.ccg.basis = append(cg.ccg.basis, cg.NewCochainFromSimplices(cg.Simplex(idx)))
+		}
+
+		return cg.ccg
+	}
+
+	for _, col := range cbm.kernelBasis() {
+		cg.ccg.basis = append(cg.ccg.basis, cg.cochainFromColumn(col))
+	}
+
+	return cg.ccg
+}
+
+func (cg *CocycleGroup) Basis() []*Cochain {
+	return cg.basis
+}
+
+func (cg *CocycleGroup) ChainGroup() *ChainGroup {
+	return cg.chainGroup
+}
+
+func (cg *CocycleGroup) Rank() int {
+	return len(cg.basis)
+}
+
+// CoboundaryGroup B^p is the subgroup of the CocycleGroup Z^p made up of the coboundaries of cochains in C^{p-1}.
+type CoboundaryGroup struct {
+	chainGroup *ChainGroup
+
+	basis []*Cochain
+}
+
+// CoboundaryGroup returns the group of p-dimensional coboundaries, which is the image of the coboundary map from C^{p-1} to C^p.
+func (cg *ChainGroup) CoboundaryGroup() *CoboundaryGroup {
+	if cg.cbg != nil {
+		return cg.cbg
+	}
+
+	cg.cbg = &CoboundaryGroup{
+		chainGroup: cg,
+		basis:      []*Cochain{},
+	}
+
+	if cg.dim == 0 {
+		return cg.cbg
+	}
+
+	cbm := cg.lowerGroup().coboundaryMap()
+	if cbm == nil {
+		return cg.cbg
+	}
+
+	for _, col := range cbm.imageBasis() {
+		cg.cbg.basis = append(cg.cbg.basis, cg.cochainFromColumn(col))
+	}
+
+	return cg.cbg
+}
+
+func (cg *CoboundaryGroup) Basis() []*Cochain {
+	return cg.basis
+}
+
+func (cg *CoboundaryGroup) ChainGroup() *ChainGroup {
+	return cg.chainGroup
+}
+
+func (cg *CoboundaryGroup) Rank() int {
+	return len(cg.basis)
+}
+
+// CohomologyGroup H^p is the quotient of Z^p and B^p: H^p = Z^p / B^p.
+// Over a field, H^p is dual to the HomologyGroup H_p and so has the same rank for p > 0.
+// H^0 is not reduced: its rank is the number of connected components, one more than the rank of the reduced H_0 of a non-empty Complex.
+//
+// More info: https://en.wikipedia.org/wiki/Cohomology
+type CohomologyGroup struct {
+	chainGroup *ChainGroup
+
+	basis []*Cochain
+}
+
+func (cg *ChainGroup) CohomologyGroup() *CohomologyGroup {
+	if cg.chg != nil {
+		return cg.chg
+	}
+
+	cg.chg = &CohomologyGroup{
+		chainGroup: cg,
+	}
+
+	return cg.chg
+}
+
+// Field returns the field of coefficients the cohomology group is computed over.
+func (hg *CohomologyGroup) Field() Field {
+	return hg.chainGroup.Field()
+}
+
+func (hg *CohomologyGroup) ChainGroup() *ChainGroup {
+	return hg.chainGroup
+}
+
+// Basis returns cocycles whose classes form a basis for the cohomology group.
+// The basis of the cocycle group is reduced against the basis of the coboundary group, keeping the cocycles which are independent of it.
+func (hg *CohomologyGroup) Basis() []*Cochain {
+	if hg.basis != nil {
+		return hg.basis
+	}
+
+	cg := hg.chainGroup
+	b := cg.CoboundaryGroup().Basis()
+	z := cg.CocycleGroup().Basis()
+
+	span := [][]*big.Rat{}
+	for _, cc := range b {
+		span = append(span, cc.column())
+	}
+	cols := [][]*big.Rat{}
+	for _, cc := range z {
+		cols = append(cols, cc.column())
+	}
+
+	hg.basis = []*Cochain{}
+	for _, j := range independentExtension(cg.Field(), span, cols) {
+		hg.basis = append(hg.basis, z[j])
+	}
+
+	return hg.basis
+}
+
+// Rank returns the rank of the cohomology group: rank Z^p - rank B^p.
+func (hg *CohomologyGroup) Rank() int {
+	cg := hg.chainGroup
+	return cg.CocycleGroup().Rank() - cg.CoboundaryGroup().Rank()
+}
+
+// CohomologyGroups returns the cohomology groups of c in dimensions 0 to p where p is the dimension of c.
+func (c *Complex) CohomologyGroups() []*CohomologyGroup {
+	groups := []*CohomologyGroup{}
+
+	for d := Dim(0); d <= c.dim; d++ {
+		groups = append(groups, c.ChainGroup(d).CohomologyGroup())
+	}
+
+	return groups
+}
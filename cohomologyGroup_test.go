@@ -0,0 +1,122 @@
+package comptop
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestChainGroup_CohomologyGroup(t *testing.T) {
+	type testcase struct {
+		name  string
+		bases []Base
+		field Field
+		ranks []int
+	}
+
+	torus := []Base{
+		{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5},
+		{3, 4, 6}, {4, 6, 7}, {4, 5, 7}, {5, 7, 8}, {3, 5, 8}, {3, 6, 8},
+		{0, 6, 7}, {0, 1, 7}, {1, 7, 8}, {1, 2, 8}, {2, 6, 8}, {0, 2, 6},
+	}
+	projectivePlane := []Base{
+		{0, 1, 2}, {0, 2, 3}, {0, 3, 4}, {0, 4, 5}, {0, 1, 5},
+		{1, 2, 4}, {2, 3, 5}, {1, 3, 4}, {2, 4, 5}, {1, 3, 5},
+	}
+
+	tests := []testcase{
+		{name: "torus over Z_2", bases: torus, field: Z2, ranks: []int{1, 2, 1}},
+		{name: "torus over Q", bases: torus, field: Q, ranks: []int{1, 2, 1}},
+		{name: "projective plane over Z_2", bases: projectivePlane, field: Z2, ranks: []int{1, 1, 1}},
+		{name: "projective plane over Q", bases: projectivePlane, field: Q, ranks: []int{1, 0, 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			c := &Complex{}
+			c.SetField(test.field)
+			c.NewSimplices(test.bases...)
+
+			for d, hg := range c.CohomologyGroups() {
+				if hg.Rank() != test.ranks[d] {
+					tt.Errorf("expected H^%d to have rank %d, got %d", d, test.ranks[d], hg.Rank())
+				}
+
+				basis := hg.Basis()
+				if len(basis) != test.ranks[d] {
+					tt.Fatalf("expected %d cocycles in the basis of H^%d, got %d", test.ranks[d], d, len(basis))
+				}
+				for _, cc := range basis {
+					if !cc.IsCocycle() {
+						tt.Fatalf("%v is not a cocycle", cc)
+					}
+				}
+
+				// Every coboundary is a cocycle
+				for _, cc := range hg.ChainGroup().CoboundaryGroup().Basis() {
+					if !cc.IsCocycle() {
+						tt.Fatalf("coboundary %v is not a cocycle", cc)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCochain_Coboundary(t *testing.T) {
+	c := &Complex{}
+	c.SetField(Q)
+	c.NewSimplices([]Base{{0, 1, 2}, {1, 2, 3}}...)
+
+	// δ of the indicator of vertex 1 is +1 on edges leaving 1 and -1 on edges entering it
+	vertex := c.ChainGroup(0).NewCochainFromSimplices(c.GetSimplex(1))
+	cb := vertex.Coboundary()
+	if x := cb.Value(c.GetSimplex(0, 1)); x.Cmp(big.NewRat(1, 1)) != 0 {
+		t.Errorf("expected δ[1] to be 1 on [0 1], got %v", x)
+	}
+	if x := cb.Value(c.GetSimplex(1, 2)); x.Cmp(big.NewRat(-1, 1)) != 0 {
+		t.Errorf("expected δ[1] to be -1 on [1 2], got %v", x)
+	}
+
+	// δδ = 0
+	for _, smplx := range c.GetdSimplices(0) {
+		if cbb := c.ChainGroup(0).NewCochainFromSimplices(smplx).Coboundary().Coboundary(); !cbb.IsZero() {
+			t.Fatalf("expected δδ%v to vanish, got %v", smplx, cbb)
+		}
+	}
+
+	// δφ(σ) = φ(∂σ)
+	edge := c.ChainGroup(1).NewCochain(map[*Simplex]*big.Rat{
+		c.GetSimplex(0, 1): big.NewRat(2, 1),
+		c.GetSimplex(1, 2): big.NewRat(3, 1),
+	})
+	for _, tri := range c.GetdSimplices(2) {
+		boundary := c.ChainGroup(2).NewChainFromSimplices(tri).Boundary()
+		if a, b := edge.Coboundary().Value(tri), edge.Evaluate(boundary); a.Cmp(b) != 0 {
+			t.Errorf("expected δφ(%v) = φ(∂%v), got %v and %v", tri, tri, a, b)
+		}
+	}
+}
+
+func TestChainGroup_CohomologyGroupInDimension0(t *testing.T) {
+	for _, f := range []Field{Z2, Q} {
+		t.Run(f.String(), func(tt *testing.T) {
+			c := &Complex{}
+			c.SetField(f)
+			c.NewSimplices([]Base{{0, 1, 2}, {3, 4}}...)
+
+			// H^0 counts the connected components while H_0 is reduced
+			if r := c.ChainGroup(0).CohomologyGroup().Rank(); r != 2 {
+				tt.Errorf("expected H^0 to have rank 2, got %d", r)
+			}
+			if r := len(c.ChainGroup(0).HomologyGroup().Basis()); r != 1 {
+				tt.Errorf("expected the reduced H_0 to have rank 1, got %d", r)
+			}
+
+			// The cached coboundary map is discarded when the complex grows
+			c.NewSimplex(2, 3)
+			if r := c.ChainGroup(0).CohomologyGroup().Rank(); r != 1 {
+				tt.Errorf("expected H^0 to have rank 1 once connected, got %d", r)
+			}
+		})
+	}
+}
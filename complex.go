@@ -12,11 +12,93 @@ type Complex struct {
 	chainGroups ChainGroups
 	principles  map[*Simplex]struct{}
 
+	// index maps the sorted vertex set of each Simplex to the Simplex
+	index   map[string]*Simplex
+	pending []*Simplex
+
+	// tree is the storage backend of the Complex, if it was built on a SimplexTree
+	tree *SimplexTree
+
+	field Field
+
+	// morse is true if Betti numbers and homology bases are computed on the MorseComplex of c, which is cached in mc
+	morse bool
+	mc    *MorseComplex
+
 	eulerChar *int
 
 	strng string
 }
 
+// NewComplexFromSimplexTree returns a Complex whose simplices are stored in st.
+// Simplex values are only created when they are asked for (e.g. GetSimplex, GetdSimplices or the simplices of a Chain);
+// chain groups, boundary maps and homology are computed from the nodes of st, and simplices added to the Complex are inserted into st.
+func NewComplexFromSimplexTree(st *SimplexTree) *Complex {
+	return &Complex{
+		dim:  st.Dim(),
+		tree: st,
+	}
+}
+
+// SimplexTree returns the SimplexTree backing c; returns nil if c isn't built on a SimplexTree.
+func (c *Complex) SimplexTree() *SimplexTree {
+	return c.tree
+}
+
+// materialize returns the Simplex stored at n, creating it if needed.
+// The Simplex takes the Index of n, which the ChainGroup of its dimension already counts in its basis.
+func (c *Complex) materialize(n *stNode) *Simplex {
+	if n.simplex != nil {
+		return n.simplex
+	}
+
+	smplx := &Simplex{
+		simplex: simplex{base: n.base(), sorted: true},
+		complex: c,
+	}
+	smplx.index = n.index
+
+	if c.chainGroups == nil {
+		c.chainGroups = ChainGroups{}
+	}
+
+	p := smplx.Dim()
+	group := c.chainGroups[p]
+	if group == nil {
+		group = c.newChainGroup(p)
+		c.chainGroups[p] = group
+	}
+	group.simplices[smplx.index] = smplx
+
+	n.simplex = smplx
+
+	return smplx
+}
+
+// insertIntoTree adds the Simplex with vertices base, and all of its faces, to the SimplexTree backing c.
+// If dp isn't nil, it is used to attach data to each newly created Simplex.
+func (c *Complex) insertIntoTree(dp DataProvider, base Base) *Simplex {
+	added := c.tree.Insert(base...)
+
+	if d := c.tree.Dim(); d > c.dim {
+		c.dim = d
+	}
+	if len(added) > 0 {
+		c.resetGroupCaches()
+	}
+
+	if dp != nil {
+		for _, b := range added {
+			smplx := c.GetSimplex(b...)
+			smplx.Data = dp(smplx.Dim(), smplx.index, base)
+		}
+	}
+
+	c.resetCache()
+
+	return c.GetSimplex(base...)
+}
+
 func (c *Complex) chaingroup(d Dim) *ChainGroup {
 	if d > c.dim {
 		return nil
@@ -35,38 +117,60 @@ func (c *Complex) chaingroup(d Dim) *ChainGroup {
 
 // GetSimplex returns the Simplex consisting of 0-simplices with base indices.
 func (c *Complex) GetSimplex(base ...Index) *Simplex {
-	s := &Simplex{complex: c}
-	s.base = base
+	if c.tree != nil {
+		n := c.tree.find(normalizeBase(base))
+		if n == nil {
+			return nil
+		}
 
-	dim := s.Dim()
+		return c.materialize(n)
+	}
 
-	if c.chainGroups == nil {
-		c.chainGroups = ChainGroups{}
+	if c.index == nil {
 		return nil
 	}
 
-	var group *ChainGroup
-	if group = c.chainGroups[dim]; group == nil {
-		return nil
+	s := &simplex{base: append(Base(nil), base...)}
+
+	return c.index[s.key()]
+}
+
+// register adds s to the lookup index of c.
+// s is connected to its faces by linkPending, once all of them have been added to c as well.
+func (c *Complex) register(s *Simplex) {
+	if c.index == nil {
+		c.index = map[string]*Simplex{}
 	}
 
-	for _, smplx := range group.simplices {
-		if smplx.Equals(s) {
-			return smplx
+	c.index[s.key()] = s
+	c.pending = append(c.pending, s)
+}
+
+// linkPending connects each newly registered Simplex with its codimension 1 faces.
+func (c *Complex) linkPending() {
+	for _, s := range c.pending {
+		if s.Dim() == 0 {
+			continue
+		}
+
+		for _, f := range s.d() {
+			face := c.index[f.key()]
+			s.facets = append(s.facets, face)
+			face.cofacets = append(face.cofacets, s)
 		}
 	}
 
-	return nil
+	c.pending = nil
 }
 
 // GetSimplexByIndex returns the Simplex of dimension d with Index idx.
 func (c *Complex) GetSimplexByIndex(idx Index, d Dim) *Simplex {
-	group := c.chainGroups[d]
+	group := c.chaingroup(d)
 	if group == nil {
 		return nil
 	}
 
-	return group.simplices[idx]
+	return group.Simplex(idx)
 }
 
 func (c *Complex) GetdSimplices(d Dim) []*Simplex {
@@ -120,25 +224,19 @@ func (c *Complex) PrincipleSimplices() *SimplicialSet {
 
 	p := map[*Simplex]struct{}{}
 
-	for d := int(c.dim); d >= 0; d-- {
-		group := c.chainGroups[Dim(d)]
-		for _, smplx := range group.simplices {
-			if d == int(c.dim) {
-				p[smplx] = struct{}{}
-				continue
-			}
-
-			higherGroup := c.chainGroups[Dim(d)+1]
-			var isFace bool
-			for _, hsmplx := range higherGroup.simplices {
-				if isFace = hsmplx.HasFace(smplx); isFace {
-					break
-				}
-			}
-
-			if !isFace {
-				p[smplx] = struct{}{}
-			}
+	if c.tree != nil {
+		for _, n := range c.tree.maximal() {
+			p[c.materialize(n)] = struct{}{}
+		}
+
+		c.principles = p
+
+		return &SimplicialSet{set: p}
+	}
+
+	for _, smplx := range c.index {
+		if len(smplx.cofacets) == 0 {
+			p[smplx] = struct{}{}
 		}
 	}
 
@@ -149,7 +247,15 @@ func (c *Complex) PrincipleSimplices() *SimplicialSet {
 
 // ReducedBettiNumbers gives the sequence of reduced Betti numbers B_0 to B_p where p is the dimension of the complex.
 // The Betti number B_d can be thought of as the number of d-dimensional holes in the Complex.
+// If c is set to use Morse reduction (see SetMorseReduction), they are computed on its MorseComplex.
 func (c *Complex) ReducedBettiNumbers() []int {
+	if c.morse {
+		betti := c.morseComplex().BettiNumbers()
+		betti[0]--
+
+		return betti
+	}
+
 	var (
 		z     int
 		betti = []int{}
@@ -176,8 +282,60 @@ func (c *Complex) BettiNumbers() []int {
 	return rb
 }
 
+// Field returns the field of coefficients used by the chains and chain groups of c.
+// Unless set otherwise with SetField, this is Z2.
+func (c *Complex) Field() Field {
+	if c.field == nil {
+		return Z2
+	}
+
+	return c.field
+}
+
+// SetMorseReduction sets whether BettiNumbers, ReducedBettiNumbers and the Basis of each HomologyGroup of c are computed on the MorseComplex of c.
+// The MorseComplex has the same homology as c and, for large meshes, far fewer cells; bases are still made of cycles of c.
+// Morse reduction is off by default.
+func (c *Complex) SetMorseReduction(on bool) {
+	c.morse = on
+	c.resetGroupCaches()
+}
+
+// morseComplex returns the MorseComplex of c, computing it on the first call after c or its Field changes.
+func (c *Complex) morseComplex() *MorseComplex {
+	if c.mc == nil {
+		c.mc = c.MorseComplex()
+	}
+
+	return c.mc
+}
+
+// SetField sets the field of coefficients used by the chains and chain groups of c.
+// All previously computed boundary maps, cycle, boundary and homology groups are discarded;
+// chains created before the change keep the coefficients they were created with.
+func (c *Complex) SetField(f Field) {
+	c.field = f
+	c.resetGroupCaches()
+}
+
+// resetGroupCaches discards the boundary maps, cycle, boundary, homology and cohomology groups computed by the chain groups of c.
+// Chain groups of a Complex built on a SimplexTree read their basis from the tree again.
+func (c *Complex) resetGroupCaches() {
+	c.mc = nil
+	for _, group := range c.chainGroups {
+		group.bm = nil
+		group.cg = nil
+		group.bg = nil
+		group.hg = nil
+		group.ihg = nil
+		group.resetCohomology()
+		group.zero = &Chain{complex: c, chaingroup: group, dim: group.dim}
+		group.synced = false
+	}
+}
+
 func (c *Complex) resetCache() {
 	c.eulerChar = nil
 	c.strng = ""
 	c.principles = nil
+	c.mc = nil
 }
@@ -94,11 +94,13 @@ func TestComplex_HomologyGroup(t *testing.T) {
 	g1 := c.ChainGroup(1)
 	bm1 := g1.BoundaryMap()
 	bm1.reduce()
+	bm1.U()
 	bm1.u.Inverse(bm1.u)
 
 	g2 := c.ChainGroup(2)
 	bm2 := g2.BoundaryMap()
 	bm2.reduce()
+	bm2.U()
 	bm2.u.Inverse(bm2.u)
 
 	z1Basis := [][]float64{}
@@ -122,3 +124,100 @@ func TestComplex_HomologyGroup(t *testing.T) {
 		t.Fatalf("Expected H_1(T^2) to have 2 generators, computed %d\n", len(hgBasis))
 	}
 }
+
+func TestHomologyGroup_BasisClasses(t *testing.T) {
+	c := &Complex{}
+	c.NewSimplices([]Base{
+		{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5},
+		{3, 4, 6}, {4, 6, 7}, {4, 5, 7}, {5, 7, 8}, {3, 5, 8}, {3, 6, 8},
+		{0, 6, 7}, {0, 1, 7}, {1, 7, 8}, {1, 2, 8}, {2, 6, 8}, {0, 2, 6},
+	}...)
+
+	hg := c.ChainGroup(1).HomologyGroup()
+
+	// Both bases must hold cycles whose classes are independent, none of them a boundary
+	for name, basis := range map[string][]*Chain{"Basis": hg.Basis(), "MinimalBasis": hg.MinimalBasis()} {
+		if len(basis) != 2 {
+			t.Fatalf("expected %s to hold 2 cycles, got %d", name, len(basis))
+		}
+		for _, chain := range basis {
+			if !chain.IsCycle() || chain.IsBoundary() {
+				t.Errorf("expected %s to hold cycles which are not boundaries, got %v", name, chain)
+			}
+		}
+		if !hg.extendsBoundaryBasis(basis) {
+			t.Errorf("expected the classes of %s to be independent", name)
+		}
+	}
+}
+
+func TestChainGroup_BoundaryGroupInTopDimension(t *testing.T) {
+	type testcase struct {
+		name  string
+		bases []Base
+		dim   Dim
+	}
+
+	tests := []testcase{
+		{name: "circle", bases: []Base{{0, 1}, {1, 2}, {0, 2}}, dim: 1},
+		{name: "sphere", bases: []Base{{0, 1, 2}, {0, 1, 3}, {0, 2, 3}, {1, 2, 3}}, dim: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			c := &Complex{}
+			c.NewSimplices(test.bases...)
+			cg := c.ChainGroup(test.dim)
+
+			// The only cycle is the sum of every top simplex
+			z := cg.CycleGroup().Basis()
+			if len(z) != 1 || len(z[0].Simplices()) != cg.Rank() {
+				tt.Fatalf("expected the sum of every %d-simplex to span the cycle group, got %v", test.dim, z)
+			}
+
+			if r := cg.BoundaryGroup().Rank(); r != 0 {
+				tt.Errorf("expected no boundaries in the top dimension, got rank %d", r)
+			}
+
+			basis := cg.HomologyGroup().Basis()
+			if len(basis) != 1 || !basis[0].Equals(z[0]) {
+				tt.Errorf("expected the fundamental class to generate H_%d, got %v", test.dim, basis)
+			}
+
+			if bn := c.BettiNumbers(); bn[test.dim] != len(basis) {
+				tt.Errorf("expected the Betti number %d to match the basis, got %v", len(basis), bn)
+			}
+		})
+	}
+}
+
+func TestComplex_GetSimplex(t *testing.T) {
+	cmplx := &Complex{}
+	smplx := cmplx.NewSimplex(0, 1, 2, 3)
+
+	base := Base{3, 1, 2}
+	face := cmplx.GetSimplex(base...)
+	if face == nil {
+		t.Fatal("expected to find face [1 2 3]")
+	}
+
+	if base[0] != 3 {
+		t.Error("GetSimplex should not reorder its argument")
+	}
+
+	if !smplx.HasFace(face) {
+		t.Errorf("expected %v to be a face of %v", face, smplx)
+	}
+
+	if cmplx.GetSimplex(0, 4) != nil {
+		t.Error("found a simplex which isn't in the complex")
+	}
+
+	if count := smplx.Faces(1).Card(); count != 6 {
+		t.Errorf("expected 6 edges, got %d", count)
+	}
+
+	if count := cmplx.GetSimplex(0).AllCofaces().Card(); count != 7 {
+		t.Errorf("expected 7 cofaces of a vertex of the tetrahedron, got %d", count)
+	}
+}
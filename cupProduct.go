@@ -0,0 +1,98 @@
+package comptop
+
+import (
+	"math/big"
+)
+
+// Cup returns the cup product of the p-cochain cc and the q-cochain a, which is the (p+q)-cochain given by the Alexander-Whitney formula:
+// (cc ⌣ a)([v_0, ..., v_{p+q}]) = cc([v_0, ..., v_p]) * a([v_p, ..., v_{p+q}]), with the vertices of each Simplex in increasing order.
+// Cup returns nil if cc and a are not in the same Complex or if the Complex has no simplices of dimension p+q.
+//
+// More info: https://en.wikipedia.org/wiki/Cup_product
+func (cc *Cochain) Cup(a *Cochain) *Cochain {
+	c := cc.chainGroup.complex
+	if a == nil || a.chainGroup.complex != c {
+		return nil
+	}
+
+	p, q := cc.Dim(), a.Dim()
+	group := c.ChainGroup(p + q)
+	if group == nil {
+		return nil
+	}
+
+	f := cc.Field()
+	values := map[*Simplex]*big.Rat{}
+
+	for _, smplx := range group.Simplices() {
+		smplx.sort()
+
+		front := cc.Value(c.GetSimplex(smplx.base[:p+1]...))
+		if front.Sign() == 0 {
+			continue
+		}
+
+		back := a.Value(c.GetSimplex(smplx.base[p:]...))
+		if back.Sign() == 0 {
+			continue
+		}
+
+		values[smplx] = fieldMul(f, front, back)
+	}
+
+	return group.NewCochain(values)
+}
+
+// Coordinates returns the coordinates of the cohomology class of cc with respect to the classes of the cocycles returned by Basis.
+// Coordinates returns nil if cc is not a cocycle of the same dimension.
+func (hg *CohomologyGroup) Coordinates(cc *Cochain) []*big.Rat {
+	cg := hg.chainGroup
+	if cc == nil || cc.chainGroup != cg || !cc.IsCocycle() {
+		return nil
+	}
+
+	basis := hg.Basis()
+
+	cols := [][]*big.Rat{}
+	for _, h := range basis {
+		cols = append(cols, h.column())
+	}
+	for _, b := range cg.CoboundaryGroup().Basis() {
+		cols = append(cols, b.column())
+	}
+
+	x, ok := fieldSolve(cg.Field(), cols, cc.column())
+	if !ok {
+		return nil
+	}
+
+	return x[:len(basis)]
+}
+
+// CupProduct returns the cup product H^p x H^q -> H^{p+q} on the bases of the cohomology groups of c.
+// The entry [i][j] holds the coordinates, in the basis of H^{p+q}, of the class of the cup product of the i^th basis cocycle of H^p with the j^th basis cocycle of H^q.
+// Together with the ranks of the cohomology groups, these tables describe the cohomology ring of c; for example, they tell the torus
+// (where the product of the two generators of H^1 generates H^2) apart from a wedge of two circles and a sphere (where every product in positive degree vanishes).
+// CupProduct returns nil if p+q is larger than the dimension of c.
+//
+// More info: https://en.wikipedia.org/wiki/Cohomology_ring
+func (c *Complex) CupProduct(p, q Dim) [][][]*big.Rat {
+	if p+q > c.dim {
+		return nil
+	}
+
+	hp := c.ChainGroup(p).CohomologyGroup()
+	hq := c.ChainGroup(q).CohomologyGroup()
+	hpq := c.ChainGroup(p + q).CohomologyGroup()
+
+	table := [][][]*big.Rat{}
+	for _, x := range hp.Basis() {
+		row := [][]*big.Rat{}
+		for _, y := range hq.Basis() {
+			row = append(row, hpq.Coordinates(x.Cup(y)))
+		}
+		table = append(table, row)
+	}
+
+	return table
+}
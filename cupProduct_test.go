@@ -0,0 +1,82 @@
+package comptop
+
+import (
+	"testing"
+)
+
+func TestComplex_CupProduct(t *testing.T) {
+	type testcase struct {
+		name  string
+		bases []Base
+		field Field
+
+		// nonZero is true if some product of two classes in H^1 is non-zero in H^2
+		nonZero bool
+	}
+
+	tests := []testcase{
+		{
+			name: "torus",
+			bases: []Base{
+				{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5},
+				{3, 4, 6}, {4, 6, 7}, {4, 5, 7}, {5, 7, 8}, {3, 5, 8}, {3, 6, 8},
+				{0, 6, 7}, {0, 1, 7}, {1, 7, 8}, {1, 2, 8}, {2, 6, 8}, {0, 2, 6},
+			},
+			field:   Q,
+			nonZero: true,
+		},
+		{
+			name: "two circles and a sphere",
+			bases: []Base{
+				{0, 1}, {1, 2}, {0, 2},
+				{0, 3}, {3, 4}, {0, 4},
+				{0, 5, 6}, {0, 5, 7}, {0, 6, 7}, {5, 6, 7},
+			},
+			field:   Q,
+			nonZero: false,
+		},
+		{
+			name: "projective plane",
+			bases: []Base{
+				{0, 1, 2}, {0, 2, 3}, {0, 3, 4}, {0, 4, 5}, {0, 1, 5},
+				{1, 2, 4}, {2, 3, 5}, {1, 3, 4}, {2, 4, 5}, {1, 3, 5},
+			},
+			field:   Z2,
+			nonZero: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			c := &Complex{}
+			c.SetField(test.field)
+			c.NewSimplices(test.bases...)
+
+			var nonZero bool
+			for _, row := range c.CupProduct(1, 1) {
+				for _, coords := range row {
+					if coords == nil {
+						tt.Fatal("cup product of two cocycles is not a cocycle")
+					}
+					for _, x := range coords {
+						if x.Sign() != 0 {
+							nonZero = true
+						}
+					}
+				}
+			}
+
+			if nonZero != test.nonZero {
+				tt.Errorf("expected a non-zero cup product on H^1: %v, got %v", test.nonZero, nonZero)
+			}
+
+			// The class of 1 in H^0 is the identity of the ring
+			one := c.ChainGroup(0).NewCochainFromSimplices(c.GetdSimplices(0)...)
+			for _, h := range c.ChainGroup(1).CohomologyGroup().Basis() {
+				if !one.Cup(h).Equals(h) {
+					tt.Errorf("expected 1 ⌣ %v to be itself", h)
+				}
+			}
+		})
+	}
+}
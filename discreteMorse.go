@@ -0,0 +1,592 @@
+package comptop
+
+import (
+	"container/heap"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// DiscreteVectorField is an acyclic matching on the simplices of a Complex.
+// Each pair matches a p-simplex with a (p+1)-simplex it is a facet of, and no Simplex is in more than one pair.
+// Acyclic means there is no closed V-path: no sequence s_0 < t_0 > s_1 < t_1 > ... > s_0, where each t_i is matched with s_i.
+// Simplices which are not matched are critical.
+//
+// More info: https://en.wikipedia.org/wiki/Discrete_Morse_theory
+type DiscreteVectorField struct {
+	complex *Complex
+
+	up   map[*Simplex]*Simplex
+	down map[*Simplex]*Simplex
+
+	// order is a total order on the simplices of each dimension in which V-paths only move forward
+	order map[*Simplex]int
+}
+
+// NewDiscreteVectorField returns the DiscreteVectorField on c matching the simplices of each pair.
+// An error is returned if a pair is not made of a Simplex and one of its cofacets, if a Simplex is in more than one pair, or if the matching is not acyclic.
+func NewDiscreteVectorField(c *Complex, pairs [][2]*Simplex) (*DiscreteVectorField, error) {
+	dvf := &DiscreteVectorField{
+		complex: c,
+		up:      map[*Simplex]*Simplex{},
+		down:    map[*Simplex]*Simplex{},
+	}
+
+	for _, pair := range pairs {
+		s, t := pair[0], pair[1]
+		if s == nil || t == nil || s.complex != c || t.complex != c {
+			return nil, fmt.Errorf("comptop: NewDiscreteVectorField: %v and %v are not both simplices of the complex", s, t)
+		}
+		if s.Dim() > t.Dim() {
+			s, t = t, s
+		}
+		if t.Dim() != s.Dim()+1 || !t.HasFace(s) {
+			return nil, fmt.Errorf("comptop: NewDiscreteVectorField: %v is not a facet of %v", s.base, t.base)
+		}
+
+		for _, smplx := range []*Simplex{s, t} {
+			if dvf.IsMatched(smplx) {
+				return nil, fmt.Errorf("comptop: NewDiscreteVectorField: %v is in more than one pair", smplx.base)
+			}
+		}
+
+		dvf.up[s] = t
+		dvf.down[t] = s
+	}
+
+	if !dvf.orderVPaths() {
+		return nil, fmt.Errorf("comptop: NewDiscreteVectorField: the matching has a closed V-path")
+	}
+
+	return dvf, nil
+}
+
+// GreedyVectorField returns a DiscreteVectorField on c built from a sequence of elementary collapses.
+// While some remaining Simplex is a free face, meaning it has exactly one remaining cofacet, the two are matched and removed;
+// when there is no free face left, a remaining Simplex of highest dimension is made critical and removed.
+// Every Simplex is removed after all of its cofacets, which makes the matching acyclic.
+// The number of critical p-simplices is at least the p^th Betti number and, for most meshes, is much smaller than the number of p-simplices.
+//
+// More info: 'Computing optimal Morse matchings' by Joswig & Pfetsch.
+func (c *Complex) GreedyVectorField() *DiscreteVectorField {
+	dvf := &DiscreteVectorField{
+		complex: c,
+		up:      map[*Simplex]*Simplex{},
+		down:    map[*Simplex]*Simplex{},
+		order:   map[*Simplex]int{},
+	}
+
+	cells := c.sortedSimplices()
+
+	alive := map[*Simplex]bool{}
+	cofacets := map[*Simplex][]*Simplex{}
+	count := map[*Simplex]int{}
+	for _, level := range cells {
+		for _, smplx := range level {
+			alive[smplx] = true
+			for _, facet := range facetsOf(smplx) {
+				cofacets[facet] = append(cofacets[facet], smplx)
+				count[facet]++
+			}
+		}
+	}
+
+	queue := []*Simplex{}
+	for _, level := range cells {
+		for _, smplx := range level {
+			if count[smplx] == 1 {
+				queue = append(queue, smplx)
+			}
+		}
+	}
+
+	var time int
+	remove := func(smplx *Simplex) {
+		alive[smplx] = false
+		dvf.order[smplx] = time
+		time++
+
+		for _, facet := range facetsOf(smplx) {
+			count[facet]--
+			if count[facet] == 1 && alive[facet] {
+				queue = append(queue, facet)
+			}
+		}
+	}
+
+	// Critical simplices are taken from the highest dimension down, in order of index
+	next := len(cells) - 1
+	pos := 0
+	for remaining := len(alive); remaining > 0; {
+		if len(queue) > 0 {
+			s := queue[0]
+			queue = queue[1:]
+			if !alive[s] || count[s] != 1 {
+				continue
+			}
+
+			var t *Simplex
+			for _, cofacet := range cofacets[s] {
+				if alive[cofacet] {
+					t = cofacet
+					break
+				}
+			}
+
+			dvf.up[s] = t
+			dvf.down[t] = s
+			remove(t)
+			remove(s)
+			remaining -= 2
+			continue
+		}
+
+		for pos >= len(cells[next]) || !alive[cells[next][pos]] {
+			if pos >= len(cells[next]) {
+				next--
+				pos = 0
+				continue
+			}
+			pos++
+		}
+
+		remove(cells[next][pos])
+		remaining--
+	}
+
+	return dvf
+}
+
+// Complex returns the Complex dvf is defined on.
+func (dvf *DiscreteVectorField) Complex() *Complex {
+	return dvf.complex
+}
+
+// Partner returns the Simplex s is matched with; returns nil if s is critical.
+func (dvf *DiscreteVectorField) Partner(s *Simplex) *Simplex {
+	if t, exists := dvf.up[s]; exists {
+		return t
+	}
+
+	return dvf.down[s]
+}
+
+// IsMatched returns true if s is in a pair of dvf.
+func (dvf *DiscreteVectorField) IsMatched(s *Simplex) bool {
+	return dvf.Partner(s) != nil
+}
+
+// IsCritical returns true if s is not in any pair of dvf.
+func (dvf *DiscreteVectorField) IsCritical(s *Simplex) bool {
+	return !dvf.IsMatched(s)
+}
+
+// Pairs returns the pairs of dvf, each with the lower dimensional Simplex first.
+// Pairs are sorted by dimension and then by the index of their lower Simplex.
+func (dvf *DiscreteVectorField) Pairs() [][2]*Simplex {
+	pairs := [][2]*Simplex{}
+	for s, t := range dvf.up {
+		pairs = append(pairs, [2]*Simplex{s, t})
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		a, b := pairs[i][0], pairs[j][0]
+		if a.Dim() != b.Dim() {
+			return a.Dim() < b.Dim()
+		}
+		return a.index < b.index
+	})
+
+	return pairs
+}
+
+// CriticalSimplices returns the critical simplices of dimension d, sorted by index.
+func (dvf *DiscreteVectorField) CriticalSimplices(d Dim) []*Simplex {
+	critical := []*Simplex{}
+	if d > dvf.complex.dim {
+		return critical
+	}
+
+	for _, smplx := range dvf.complex.sortedSimplices()[d] {
+		if dvf.IsCritical(smplx) {
+			critical = append(critical, smplx)
+		}
+	}
+
+	return critical
+}
+
+// orderVPaths orders the simplices of each dimension so that every V-path only moves forward;
+// returns false if there is a closed V-path.
+func (dvf *DiscreteVectorField) orderVPaths() bool {
+	dvf.order = map[*Simplex]int{}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[*Simplex]int{}
+
+	var (
+		time  int
+		visit func(s *Simplex) bool
+	)
+
+	// A V-path steps from s to each other facet of the Simplex s is matched with
+	visit = func(s *Simplex) bool {
+		state[s] = visiting
+
+		if t, matched := dvf.up[s]; matched {
+			for _, next := range facetsOf(t) {
+				if next == s {
+					continue
+				}
+
+				switch state[next] {
+				case visiting:
+					return false
+				case 0:
+					if !visit(next) {
+						return false
+					}
+				}
+			}
+		}
+
+		state[s] = done
+		// Simplices are finished after everything reachable from them, so later paths get smaller times
+		dvf.order[s] = -time
+		time++
+
+		return true
+	}
+
+	for _, level := range dvf.complex.sortedSimplices() {
+		for _, smplx := range level {
+			if state[smplx] == 0 && !visit(smplx) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// flow rewrites chain, in place, into a chain homologous to it in which no Simplex is matched with a Simplex one dimension higher.
+// The boundary of the matched Simplex, scaled to cancel the coefficient, is subtracted from the chain, following V-paths forward.
+// If track is not nil, the matched simplices used, with their scale factors, are added to it.
+func (dvf *DiscreteVectorField) flow(f Field, chain map[*Simplex]*big.Rat, track map[*Simplex]*big.Rat) {
+	// Handle the earliest Simplex in the V-path order first; every Simplex it adds comes later
+	queued := map[*Simplex]bool{}
+	pq := &simplexQueue{}
+	push := func(s *Simplex) {
+		if !queued[s] {
+			queued[s] = true
+			heap.Push(pq, simplexOrder{simplex: s, order: dvf.order[s]})
+		}
+	}
+	for s := range chain {
+		push(s)
+	}
+
+	for pq.Len() > 0 {
+		s := heap.Pop(pq).(simplexOrder).simplex
+
+		t, matched := dvf.up[s]
+		x := chain[s]
+		if !matched || x == nil || x.Sign() == 0 {
+			continue
+		}
+
+		// Subtract lambda * d(t), where lambda cancels the coefficient of s
+		lambda := fieldMul(f, x, big.NewRat(int64(t.faceSign(&s.simplex)), 1))
+		if track != nil {
+			y := fieldSub(f, new(big.Rat), lambda)
+			if z, exists := track[t]; exists {
+				y = fieldAdd(f, y, z)
+			}
+			track[t] = y
+		}
+
+		for _, facet := range facetsOf(t) {
+			y := fieldMul(f, lambda, big.NewRat(int64(t.faceSign(&facet.simplex)), 1))
+			z, exists := chain[facet]
+			if !exists {
+				z = new(big.Rat)
+			}
+			chain[facet] = fieldSub(f, z, y)
+			push(facet)
+		}
+	}
+}
+
+// simplexOrder is a Simplex with its position in the V-path order.
+type simplexOrder struct {
+	simplex *Simplex
+	order   int
+}
+
+// simplexQueue is a min-heap of simplices by position in the V-path order.
+type simplexQueue []simplexOrder
+
+func (q simplexQueue) Len() int            { return len(q) }
+func (q simplexQueue) Less(i, j int) bool  { return q[i].order < q[j].order }
+func (q simplexQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *simplexQueue) Push(x interface{}) { *q = append(*q, x.(simplexOrder)) }
+func (q *simplexQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+
+	return item
+}
+
+// MorseComplex returns the Morse complex of dvf.
+func (dvf *DiscreteVectorField) MorseComplex() *MorseComplex {
+	mc := &MorseComplex{
+		vectorField: dvf,
+		critical:    map[Dim][]*Simplex{},
+		boundaries:  map[Dim]fieldMatrix{},
+	}
+
+	for d := Dim(0); d <= dvf.complex.dim; d++ {
+		mc.critical[d] = dvf.CriticalSimplices(d)
+	}
+
+	return mc
+}
+
+// MorseComplex is the chain complex spanned by the critical simplices of a DiscreteVectorField.
+// Its boundary map counts, with signs, the V-paths from the facets of a critical Simplex down to critical simplices one dimension lower.
+// It has the same homology as the Complex it comes from, and is usually much smaller.
+//
+// More info: 'Morse theory for cell complexes' by Forman.
+type MorseComplex struct {
+	vectorField *DiscreteVectorField
+
+	critical   map[Dim][]*Simplex
+	boundaries map[Dim]fieldMatrix
+}
+
+// MorseComplex returns the Morse complex of the DiscreteVectorField returned by GreedyVectorField.
+func (c *Complex) MorseComplex() *MorseComplex {
+	return c.GreedyVectorField().MorseComplex()
+}
+
+// VectorField returns the DiscreteVectorField mc was built from.
+func (mc *MorseComplex) VectorField() *DiscreteVectorField {
+	return mc.vectorField
+}
+
+// Field returns the field of coefficients of the Complex mc was built from.
+func (mc *MorseComplex) Field() Field {
+	return mc.vectorField.complex.Field()
+}
+
+// Dim returns the dimension of the Complex mc was built from.
+func (mc *MorseComplex) Dim() Dim {
+	return mc.vectorField.complex.dim
+}
+
+// CriticalSimplices returns the critical simplices of dimension d, which form the basis of the d-chains of mc.
+func (mc *MorseComplex) CriticalSimplices(d Dim) []*Simplex {
+	return mc.critical[d]
+}
+
+// Rank returns the number of critical simplices of dimension d.
+func (mc *MorseComplex) Rank(d Dim) int {
+	return len(mc.critical[d])
+}
+
+// BoundaryMatrix returns the matrix of the boundary map of mc from dimension d to dimension d-1.
+// Rows and columns follow the critical simplices of dimensions d-1 and d, as returned by CriticalSimplices.
+// Returns nil if either dimension has no critical simplices.
+func (mc *MorseComplex) BoundaryMatrix(d Dim) mat.Matrix {
+	b := mc.boundary(d)
+	if b == nil {
+		return nil
+	}
+
+	return b.dense()
+}
+
+// BettiNumbers gives the sequence of Betti numbers B_0 to B_p computed from mc, where p is the dimension of the Complex it was built from.
+// These equal the BettiNumbers of that Complex.
+func (mc *MorseComplex) BettiNumbers() []int {
+	f := mc.Field()
+
+	betti := []int{}
+	for d := Dim(0); d <= mc.Dim(); d++ {
+		b := mc.Rank(d)
+		if m := mc.boundary(d); m != nil {
+			b -= fieldRank(f, columns(m)...)
+		}
+		if m := mc.boundary(d + 1); m != nil {
+			b -= fieldRank(f, columns(m)...)
+		}
+		betti = append(betti, b)
+	}
+
+	return betti
+}
+
+// HomologyBasis returns cycles of the Complex mc was built from whose homology classes form a basis of its homology in dimension d.
+// A basis of the homology of mc is computed first, and each of its cycles is carried back along V-paths to a cycle of the Complex.
+// As for the Basis of a HomologyGroup, homology in dimension 0 is reduced: 0-cycles are the 0-chains whose coefficients sum to zero,
+// so there is one cycle fewer than there are connected components.
+func (mc *MorseComplex) HomologyBasis(d Dim) []*Chain {
+	f := mc.Field()
+	n := mc.Rank(d)
+	if n == 0 {
+		return []*Chain{}
+	}
+
+	// Cycles of mc are the kernel of its boundary map, or of the augmentation in dimension 0 (critical vertices lift to themselves)
+	cycles := [][]*big.Rat{}
+	if m := mc.boundary(d); m != nil {
+		cycles = fieldKernel(f, columns(m))
+	} else if d == 0 {
+		augmentation := make([][]*big.Rat, n)
+		for j := range augmentation {
+			augmentation[j] = []*big.Rat{big.NewRat(1, 1)}
+		}
+		cycles = fieldKernel(f, augmentation)
+	} else {
+		for j := 0; j < n; j++ {
+			e := make([]*big.Rat, n)
+			for i := range e {
+				e[i] = new(big.Rat)
+			}
+			e[j] = big.NewRat(1, 1)
+			cycles = append(cycles, e)
+		}
+	}
+
+	// Extend a basis of the boundaries by cycles
+	independent := [][]*big.Rat{}
+	if m := mc.boundary(d + 1); m != nil {
+		for _, col := range columns(m) {
+			if fieldRank(f, append(independent, col)...) > len(independent) {
+				independent = append(independent, col)
+			}
+		}
+	}
+
+	basis := []*Chain{}
+	for _, z := range cycles {
+		if fieldRank(f, append(independent, z)...) == len(independent) {
+			continue
+		}
+		independent = append(independent, z)
+		basis = append(basis, mc.lift(d, z))
+	}
+
+	return basis
+}
+
+// lift returns the cycle of the Complex corresponding to the cycle of mc of dimension d with coordinates z.
+// Starting from the critical simplices, the matched (d-1)-simplices in the boundary are cancelled by adding the d-simplices they are matched with.
+func (mc *MorseComplex) lift(d Dim, z []*big.Rat) *Chain {
+	f := mc.Field()
+	group := mc.vectorField.complex.ChainGroup(d)
+
+	coeffs := map[*Simplex]*big.Rat{}
+	for idx, x := range z {
+		if x.Sign() != 0 {
+			coeffs[mc.critical[d][idx]] = f.Reduce(x)
+		}
+	}
+
+	if d > 0 {
+		boundary := map[*Simplex]*big.Rat{}
+		for s, x := range coeffs {
+			for _, facet := range facetsOf(s) {
+				y := fieldMul(f, x, big.NewRat(int64(s.faceSign(&facet.simplex)), 1))
+				if w, exists := boundary[facet]; exists {
+					y = fieldAdd(f, y, w)
+				}
+				boundary[facet] = y
+			}
+		}
+
+		mc.vectorField.flow(f, boundary, coeffs)
+	}
+
+	return group.NewChainFromCoefficients(coeffs)
+}
+
+// boundary returns the boundary matrix of mc from dimension d to dimension d-1; returns nil if either dimension has no critical simplices.
+func (mc *MorseComplex) boundary(d Dim) fieldMatrix {
+	if d == 0 || d > mc.Dim() || mc.Rank(d) == 0 || mc.Rank(d-1) == 0 {
+		return nil
+	}
+
+	if b, exists := mc.boundaries[d]; exists {
+		return b
+	}
+
+	f := mc.Field()
+
+	rows := map[*Simplex]int{}
+	for row, smplx := range mc.critical[d-1] {
+		rows[smplx] = row
+	}
+
+	b := newFieldMatrix(mc.Rank(d-1), mc.Rank(d))
+	for col, t := range mc.critical[d] {
+		chain := map[*Simplex]*big.Rat{}
+		for _, facet := range facetsOf(t) {
+			chain[facet] = f.Reduce(big.NewRat(int64(t.faceSign(&facet.simplex)), 1))
+		}
+
+		mc.vectorField.flow(f, chain, nil)
+
+		for smplx, x := range chain {
+			if row, critical := rows[smplx]; critical {
+				b[row][col] = f.Reduce(x)
+			}
+		}
+	}
+
+	mc.boundaries[d] = b
+
+	return b
+}
+
+// sortedSimplices returns the simplices of c grouped by dimension, each group sorted by index.
+func (c *Complex) sortedSimplices() [][]*Simplex {
+	cells := [][]*Simplex{}
+	for d := Dim(0); d <= c.dim; d++ {
+		level := c.GetdSimplices(d)
+		sort.Slice(level, func(i, j int) bool {
+			return level[i].index < level[j].index
+		})
+		cells = append(cells, level)
+	}
+
+	return cells
+}
+
+// facetsOf returns the codimension 1 faces of s; returns nil if s is a vertex.
+func facetsOf(s *Simplex) []*Simplex {
+	if s.Dim() == 0 {
+		return nil
+	}
+
+	return s.Faces(s.Dim() - 1).Slice()
+}
+
+// columns returns the columns of a.
+func columns(a fieldMatrix) [][]*big.Rat {
+	_, n := a.dims()
+
+	cols := make([][]*big.Rat, n)
+	for j := range cols {
+		cols[j] = a.col(j)
+	}
+
+	return cols
+}
@@ -0,0 +1,169 @@
+package comptop
+
+import (
+	"testing"
+)
+
+func TestComplex_MorseComplex(t *testing.T) {
+	torus := []Base{
+		{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5},
+		{3, 4, 6}, {4, 6, 7}, {4, 5, 7}, {5, 7, 8}, {3, 5, 8}, {3, 6, 8},
+		{0, 6, 7}, {0, 1, 7}, {1, 7, 8}, {1, 2, 8}, {2, 6, 8}, {0, 2, 6},
+	}
+	projectivePlane := []Base{
+		{0, 1, 2}, {0, 2, 3}, {0, 3, 4}, {0, 4, 5}, {0, 1, 5},
+		{1, 2, 4}, {2, 3, 5}, {1, 3, 4}, {2, 4, 5}, {1, 3, 5},
+	}
+
+	table := []struct {
+		name       string
+		bases      []Base
+		field      Field
+		expectedBN []int
+	}{
+		{"torus/Z_2", torus, Z2, []int{1, 2, 1}},
+		{"torus/Q", torus, Q, []int{1, 2, 1}},
+		{"projective plane/Z_2", projectivePlane, Z2, []int{1, 1, 1}},
+		{"projective plane/Q", projectivePlane, Q, []int{1, 0, 0}},
+		{"disk", []Base{{0, 1, 2}, {0, 2, 3}, {0, 3, 4}}, Q, []int{1, 0, 0}},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(tt *testing.T) {
+			c := &Complex{}
+			c.SetField(row.field)
+			c.NewSimplices(row.bases...)
+
+			dvf := c.GreedyVectorField()
+			if _, err := NewDiscreteVectorField(c, dvf.Pairs()); err != nil {
+				tt.Fatalf("expected the greedy matching to be a valid vector field: %v", err)
+			}
+
+			mc := dvf.MorseComplex()
+			bn := mc.BettiNumbers()
+			for d, b := range row.expectedBN {
+				if bn[d] != b {
+					tt.Errorf("expected Betti numbers %v, got %v", row.expectedBN, bn)
+					break
+				}
+				if mc.Rank(Dim(d)) < b {
+					tt.Errorf("expected at least %d critical %d-simplices, got %d", b, d, mc.Rank(Dim(d)))
+				}
+
+				// Homology in dimension 0 is reduced, as for a HomologyGroup
+				rb := b
+				if d == 0 {
+					rb--
+				}
+
+				basis := mc.HomologyBasis(Dim(d))
+				if len(basis) != rb {
+					tt.Errorf("expected %d cycles in dimension %d, got %d", rb, d, len(basis))
+				}
+				for _, cycle := range basis {
+					if !cycle.IsCycle() {
+						tt.Errorf("expected %v to be a cycle", cycle)
+					}
+					if cycle.IsBoundary() {
+						tt.Errorf("expected %v not to be a boundary", cycle)
+					}
+				}
+
+				// Cycles in dimension 1 must have independent classes
+				if d == 1 && len(basis) == 2 {
+					hg := c.ChainGroup(1).HomologyGroup()
+					if fieldRank(row.field, hg.Coordinates(basis[0]), hg.Coordinates(basis[1])) != 2 {
+						tt.Errorf("expected independent classes, got %v", basis)
+					}
+				}
+			}
+
+			if total := mc.Rank(0) + mc.Rank(1) + mc.Rank(2); total >= len(c.GetdSimplices(0))+len(c.GetdSimplices(1))+len(c.GetdSimplices(2)) {
+				tt.Errorf("expected fewer critical simplices than simplices, got %d", total)
+			}
+		})
+	}
+}
+
+func TestNewDiscreteVectorField(t *testing.T) {
+	c := &Complex{}
+	c.NewSimplices([]Base{{0, 1}, {1, 2}, {0, 2}}...)
+
+	v0, v1, v2 := c.GetSimplex(0), c.GetSimplex(1), c.GetSimplex(2)
+	e01, e12, e02 := c.GetSimplex(0, 1), c.GetSimplex(1, 2), c.GetSimplex(0, 2)
+
+	table := []struct {
+		name  string
+		pairs [][2]*Simplex
+		valid bool
+	}{
+		{"path", [][2]*Simplex{{v1, e01}, {v2, e12}}, true},
+		{"reversed pair", [][2]*Simplex{{e01, v1}}, true},
+		{"closed V-path", [][2]*Simplex{{v0, e01}, {v1, e12}, {v2, e02}}, false},
+		{"not a facet", [][2]*Simplex{{v0, e12}}, false},
+		{"matched twice", [][2]*Simplex{{v1, e01}, {v1, e12}}, false},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(tt *testing.T) {
+			dvf, err := NewDiscreteVectorField(c, row.pairs)
+			if row.valid != (err == nil) {
+				tt.Fatalf("expected valid = %v, got error %v", row.valid, err)
+			}
+			if !row.valid {
+				return
+			}
+
+			bn := dvf.MorseComplex().BettiNumbers()
+			if bn[0] != 1 || bn[1] != 1 {
+				tt.Errorf("expected Betti numbers [1 1], got %v", bn)
+			}
+		})
+	}
+}
+
+func TestComplex_SetMorseReduction(t *testing.T) {
+	// Two disjoint hollow tetrahedra and a circle
+	bases := []Base{
+		{0, 1, 2}, {0, 1, 3}, {0, 2, 3}, {1, 2, 3},
+		{4, 5, 6}, {4, 5, 7}, {4, 6, 7}, {5, 6, 7},
+		{8, 9}, {9, 10}, {8, 10},
+	}
+
+	for _, f := range []Field{Z2, Q} {
+		t.Run(f.String(), func(tt *testing.T) {
+			c := &Complex{}
+			c.SetField(f)
+			c.NewSimplices(bases...)
+
+			expected := c.BettiNumbers()
+			expectedReduced := c.ReducedBettiNumbers()
+
+			c.SetMorseReduction(true)
+
+			bn := c.BettiNumbers()
+			rbn := c.ReducedBettiNumbers()
+			for d := range expected {
+				if bn[d] != expected[d] || rbn[d] != expectedReduced[d] {
+					tt.Fatalf("expected Betti numbers %v and %v, got %v and %v", expected, expectedReduced, bn, rbn)
+				}
+
+				basis := c.ChainGroup(Dim(d)).HomologyGroup().Basis()
+				if len(basis) != rbn[d] {
+					tt.Errorf("expected %d cycles in dimension %d, got %d", rbn[d], d, len(basis))
+				}
+				for _, cycle := range basis {
+					if !cycle.IsCycle() || cycle.IsBoundary() {
+						tt.Errorf("expected %v to be a cycle and not a boundary", cycle)
+					}
+				}
+			}
+
+			// The Morse complex follows changes to the Complex
+			c.NewSimplex(8, 9, 10)
+			if bn := c.BettiNumbers(); bn[0] != 3 || bn[1] != 0 {
+				tt.Errorf("expected Betti numbers [3 0 2] after filling the circle, got %v", bn)
+			}
+		})
+	}
+}
@@ -75,7 +75,11 @@ func (c *Complex) EulerChar() int {
 	)
 
 	for d := Dim(0); d <= c.dim; d++ {
-		m += a * len(c.chaingroup(d).simplices)
+		if c.tree != nil {
+			m += a * c.tree.Count(d)
+		} else {
+			m += a * len(c.chaingroup(d).simplices)
+		}
 		a *= -1
 	}
 
@@ -0,0 +1,392 @@
+package comptop
+
+import (
+	"fmt"
+	"math/big"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Field is a field of coefficients for chains.
+// Elements of a Field are represented by rational numbers; Reduce maps a rational number onto its canonical representative in the Field.
+// The Field of a Complex determines the coefficients of its chains and the homology computed by its chain groups.
+//
+// More info: https://en.wikipedia.org/wiki/Field_(mathematics)
+type Field interface {
+	// Characteristic returns p for the field Z_p and 0 for the rationals.
+	Characteristic() int
+
+	// Reduce returns the canonical representative of x in the Field.
+	// Reduce panics if x has no image in the Field, such as 1/p in Z_p.
+	Reduce(x *big.Rat) *big.Rat
+
+	// Inverse returns the multiplicative inverse of the non-zero element x.
+	Inverse(x *big.Rat) *big.Rat
+
+	String() string
+}
+
+// Z2 is the field with two elements; it is the default Field of every Complex.
+var Z2 Field = Zp(2)
+
+// Q is the field of rational numbers.
+var Q Field = rationals{}
+
+type zp struct {
+	p *big.Int
+}
+
+// Zp returns the field of integers modulo p. p must be prime.
+func Zp(p int) Field {
+	if !big.NewInt(int64(p)).ProbablyPrime(0) {
+		panic(fmt.Sprintf("comptop: Zp: %d is not prime", p))
+	}
+
+	return zp{p: big.NewInt(int64(p))}
+}
+
+func (f zp) Characteristic() int {
+	return int(f.p.Int64())
+}
+
+func (f zp) Reduce(x *big.Rat) *big.Rat {
+	// a / b = a * b^-1 (mod p)
+	n := new(big.Int).Mod(x.Num(), f.p)
+	if !x.IsInt() {
+		d := new(big.Int).ModInverse(x.Denom(), f.p)
+		if d == nil {
+			panic(fmt.Sprintf("comptop: %v: %v has no image, its denominator is divisible by %v", f, x, f.p))
+		}
+		n.Mul(n, d)
+		n.Mod(n, f.p)
+	}
+
+	return new(big.Rat).SetInt(n)
+}
+
+func (f zp) Inverse(x *big.Rat) *big.Rat {
+	n := f.Reduce(x).Num()
+	return new(big.Rat).SetInt(new(big.Int).ModInverse(n, f.p))
+}
+
+func (f zp) String() string {
+	return fmt.Sprintf("Z_%d", f.p)
+}
+
+type rationals struct{}
+
+func (rationals) Characteristic() int {
+	return 0
+}
+
+func (rationals) Reduce(x *big.Rat) *big.Rat {
+	return new(big.Rat).Set(x)
+}
+
+func (rationals) Inverse(x *big.Rat) *big.Rat {
+	return new(big.Rat).Inv(x)
+}
+
+func (rationals) String() string {
+	return "Q"
+}
+
+// isBinary returns true if f is Z_2, which is handled by the boolean chain arithmetic.
+func isBinary(f Field) bool {
+	return f == nil || f.Characteristic() == 2
+}
+
+func fieldAdd(f Field, a, b *big.Rat) *big.Rat {
+	return f.Reduce(new(big.Rat).Add(a, b))
+}
+
+func fieldSub(f Field, a, b *big.Rat) *big.Rat {
+	return f.Reduce(new(big.Rat).Sub(a, b))
+}
+
+func fieldMul(f Field, a, b *big.Rat) *big.Rat {
+	return f.Reduce(new(big.Rat).Mul(a, b))
+}
+
+// fieldMatrix is a dense matrix with entries in a Field.
+type fieldMatrix [][]*big.Rat
+
+func newFieldMatrix(m, n int) fieldMatrix {
+	a := make(fieldMatrix, m)
+	for row := range a {
+		a[row] = make([]*big.Rat, n)
+		for col := range a[row] {
+			a[row][col] = new(big.Rat)
+		}
+	}
+
+	return a
+}
+
+func newFieldIdentity(n int) fieldMatrix {
+	a := newFieldMatrix(n, n)
+	for i := 0; i < n; i++ {
+		a[i][i].SetInt64(1)
+	}
+
+	return a
+}
+
+func (a fieldMatrix) dims() (int, int) {
+	if len(a) == 0 {
+		return 0, 0
+	}
+
+	return len(a), len(a[0])
+}
+
+func (a fieldMatrix) col(j int) []*big.Rat {
+	col := make([]*big.Rat, len(a))
+	for row := range a {
+		col[row] = a[row][j]
+	}
+
+	return col
+}
+
+// dense returns a float64 approximation of a, exact for entries in Z_p.
+func (a fieldMatrix) dense() *mat.Dense {
+	m, n := a.dims()
+	if m == 0 || n == 0 {
+		return nil
+	}
+
+	d := mat.NewDense(m, n, nil)
+	for row := 0; row < m; row++ {
+		for col := 0; col < n; col++ {
+			x, _ := a[row][col].Float64()
+			d.Set(row, col, x)
+		}
+	}
+
+	return d
+}
+
+// fieldRank returns the rank over f of the matrix whose columns are cols.
+func fieldRank(f Field, cols ...[]*big.Rat) int {
+	if len(cols) == 0 {
+		return 0
+	}
+
+	m := len(cols[0])
+	a := newFieldMatrix(m, len(cols))
+	for j, col := range cols {
+		for i, x := range col {
+			a[i][j] = f.Reduce(x)
+		}
+	}
+
+	var rank int
+	for col := 0; col < len(cols) && rank < m; col++ {
+		pivot := -1
+		for row := rank; row < m; row++ {
+			if a[row][col].Sign() != 0 {
+				pivot = row
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+
+		a[rank], a[pivot] = a[pivot], a[rank]
+		inv := f.Inverse(a[rank][col])
+		for row := rank + 1; row < m; row++ {
+			if a[row][col].Sign() == 0 {
+				continue
+			}
+			q := fieldMul(f, a[row][col], inv)
+			for c := col; c < len(cols); c++ {
+				a[row][c] = fieldSub(f, a[row][c], fieldMul(f, q, a[rank][c]))
+			}
+		}
+
+		rank++
+	}
+
+	return rank
+}
+
+// independentExtension returns, in increasing order, the indices of the columns in cols which are independent over f of the columns in span and of the columns in cols before them.
+// The columns in span are expected to be linearly independent.
+func independentExtension(f Field, span, cols [][]*big.Rat) []int {
+	kept := []int{}
+
+	all := append(append([][]*big.Rat{}, span...), cols...)
+	if len(all) == 0 {
+		return kept
+	}
+
+	if isBinary(f) {
+		a := newGF2Matrix(len(all[0]), len(all))
+		for j, col := range all {
+			for i, x := range col {
+				if x.Sign() != 0 {
+					a.set(i, j)
+				}
+			}
+		}
+
+		for _, j := range a.independentColumns() {
+			if j >= len(span) {
+				kept = append(kept, j-len(span))
+			}
+		}
+
+		return kept
+	}
+
+	basis := append([][]*big.Rat{}, span...)
+	for j, col := range cols {
+		if fieldRank(f, append(basis, col)...) == len(basis)+1 {
+			basis = append(basis, col)
+			kept = append(kept, j)
+		}
+	}
+
+	return kept
+}
+
+// fieldSolve returns coefficients x over f such that the sum of x_j * cols_j is b.
+// The second return value is false if b is not in the span of cols.
+func fieldSolve(f Field, cols [][]*big.Rat, b []*big.Rat) ([]*big.Rat, bool) {
+	n := len(cols)
+	m := len(b)
+
+	// Row reduce the augmented matrix [cols | b]
+	a := newFieldMatrix(m, n+1)
+	for i := 0; i < m; i++ {
+		for j, col := range cols {
+			a[i][j] = f.Reduce(col[i])
+		}
+		a[i][n] = f.Reduce(b[i])
+	}
+
+	pivotCols := []int{}
+	rank := 0
+	for col := 0; col < n && rank < m; col++ {
+		pivot := -1
+		for row := rank; row < m; row++ {
+			if a[row][col].Sign() != 0 {
+				pivot = row
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+
+		a[rank], a[pivot] = a[pivot], a[rank]
+		inv := f.Inverse(a[rank][col])
+		for c := col; c <= n; c++ {
+			a[rank][c] = fieldMul(f, a[rank][c], inv)
+		}
+		for row := 0; row < m; row++ {
+			if row == rank || a[row][col].Sign() == 0 {
+				continue
+			}
+			q := a[row][col]
+			for c := col; c <= n; c++ {
+				a[row][c] = fieldSub(f, a[row][c], fieldMul(f, q, a[rank][c]))
+			}
+		}
+
+		pivotCols = append(pivotCols, col)
+		rank++
+	}
+
+	// b is in the span only if the reduced system is consistent
+	for row := rank; row < m; row++ {
+		if a[row][n].Sign() != 0 {
+			return nil, false
+		}
+	}
+
+	x := make([]*big.Rat, n)
+	for j := range x {
+		x[j] = new(big.Rat)
+	}
+	for row, col := range pivotCols {
+		x[col] = a[row][n]
+	}
+
+	return x, true
+}
+
+// fieldKernel returns a basis over f of the vectors x such that the sum of x_j * cols_j is 0.
+func fieldKernel(f Field, cols [][]*big.Rat) [][]*big.Rat {
+	n := len(cols)
+	if n == 0 {
+		return [][]*big.Rat{}
+	}
+	m := len(cols[0])
+
+	// Bring the matrix to reduced row echelon form
+	a := newFieldMatrix(m, n)
+	for i := 0; i < m; i++ {
+		for j, col := range cols {
+			a[i][j] = f.Reduce(col[i])
+		}
+	}
+
+	pivotCols := []int{}
+	isPivot := make([]bool, n)
+	rank := 0
+	for col := 0; col < n && rank < m; col++ {
+		pivot := -1
+		for row := rank; row < m; row++ {
+			if a[row][col].Sign() != 0 {
+				pivot = row
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+
+		a[rank], a[pivot] = a[pivot], a[rank]
+		inv := f.Inverse(a[rank][col])
+		for c := col; c < n; c++ {
+			a[rank][c] = fieldMul(f, a[rank][c], inv)
+		}
+		for row := 0; row < m; row++ {
+			if row == rank || a[row][col].Sign() == 0 {
+				continue
+			}
+			q := a[row][col]
+			for c := col; c < n; c++ {
+				a[row][c] = fieldSub(f, a[row][c], fieldMul(f, q, a[rank][c]))
+			}
+		}
+
+		pivotCols = append(pivotCols, col)
+		isPivot[col] = true
+		rank++
+	}
+
+	// Each free column gives a kernel vector
+	kernel := [][]*big.Rat{}
+	for free := 0; free < n; free++ {
+		if isPivot[free] {
+			continue
+		}
+
+		x := make([]*big.Rat, n)
+		for j := range x {
+			x[j] = new(big.Rat)
+		}
+		x[free] = big.NewRat(1, 1)
+		for row, col := range pivotCols {
+			x[col] = fieldSub(f, new(big.Rat), a[row][free])
+		}
+
+		kernel = append(kernel, x)
+	}
+
+	return kernel
+}
@@ -0,0 +1,182 @@
+package comptop
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestComplex_SetField(t *testing.T) {
+	projectivePlane := []Base{
+		{0, 1, 2}, {0, 2, 3}, {0, 3, 4}, {0, 4, 5}, {0, 1, 5},
+		{1, 2, 4}, {2, 3, 5}, {1, 3, 4}, {2, 4, 5}, {1, 3, 5},
+	}
+
+	type testcase struct {
+		field      Field
+		expectedBN []int
+	}
+
+	tests := []testcase{
+		{Z2, []int{1, 1, 1}},
+		{Zp(3), []int{1, 0, 0}},
+		{Q, []int{1, 0, 0}},
+	}
+
+	c := &Complex{}
+	c.NewSimplices(projectivePlane...)
+
+	for _, test := range tests {
+		t.Run(test.field.String(), func(tt *testing.T) {
+			c.SetField(test.field)
+
+			bn := c.BettiNumbers()
+			if len(bn) != len(test.expectedBN) {
+				tt.Fatalf("invalid number of Betti numbers: %v", bn)
+			}
+
+			for idx, ebn := range test.expectedBN {
+				if bn[idx] != ebn {
+					tt.Fatalf("Betti number %d is wrong; expected %d received %d", idx, ebn, bn[idx])
+				}
+			}
+
+			if count := len(c.ChainGroup(1).HomologyGroup().Basis()); count != test.expectedBN[1] {
+				tt.Errorf("expected %d generators for H_1, got %d", test.expectedBN[1], count)
+			}
+		})
+	}
+}
+
+func TestHomologyGroup_BasisOverQ(t *testing.T) {
+	c := &Complex{}
+	c.NewSimplices([]Base{
+		{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5},
+		{3, 4, 6}, {4, 6, 7}, {4, 5, 7}, {5, 7, 8}, {3, 5, 8}, {3, 6, 8},
+		{0, 6, 7}, {0, 1, 7}, {1, 7, 8}, {1, 2, 8}, {2, 6, 8}, {0, 2, 6},
+	}...)
+	c.SetField(Q)
+
+	basis := c.ChainGroup(1).HomologyGroup().Basis()
+	if len(basis) != 2 {
+		t.Fatalf("expected H_1(T^2; Q) to have 2 generators, got %d", len(basis))
+	}
+
+	for _, chain := range basis {
+		if bndry := chain.Boundary(); !bndry.IsZero() {
+			t.Errorf("expected %v to be a cycle, got boundary %v", chain, bndry)
+		}
+	}
+}
+
+func TestChain_AddOverZp(t *testing.T) {
+	c := &Complex{}
+	c.NewSimplex(0, 1, 2)
+	c.SetField(Zp(3))
+
+	cg := c.ChainGroup(1)
+	a := cg.Singleton(c.GetSimplex(0, 1))
+
+	twice := a.Add(a)
+	if x := twice.Coefficient(c.GetSimplex(0, 1)); x.Cmp(big.NewRat(2, 1)) != 0 {
+		t.Errorf("expected coefficient 2, got %v", x)
+	}
+
+	if thrice := twice.Add(a); !thrice.IsZero() {
+		t.Errorf("expected 3a = 0 over Z_3, got %v", thrice)
+	}
+
+	// The oriented boundary of [0 1 2] is [1 2] - [0 2] + [0 1]
+	triangle := c.ChainGroup(2).Singleton(c.GetSimplex(0, 1, 2))
+	bndry := triangle.Boundary()
+	if x := bndry.Coefficient(c.GetSimplex(0, 2)); x.Cmp(big.NewRat(2, 1)) != 0 {
+		t.Errorf("expected coefficient -1 = 2 on [0 2], got %v", x)
+	}
+
+	// Rebuild the boundary so that it isn't already known to be a cycle
+	coeffs := map[*Simplex]*big.Rat{}
+	for _, smplx := range bndry.Simplices() {
+		coeffs[smplx] = bndry.Coefficient(smplx)
+	}
+	if bb := cg.NewChainFromCoefficients(coeffs).Boundary(); !bb.IsZero() {
+		t.Errorf("failed the fundamental lemma of homology: %v", bb)
+	}
+}
+
+func TestZp_Reduce(t *testing.T) {
+	f := Zp(5)
+
+	if x := f.Reduce(big.NewRat(1, 2)); x.Cmp(big.NewRat(3, 1)) != 0 {
+		t.Errorf("expected 1/2 = 3 in Z_5, got %v", x)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic when reducing 1/5 into Z_5")
+		}
+	}()
+	f.Reduce(big.NewRat(1, 5))
+}
+
+func TestChainGroup_ChainFromCoefficientVector(t *testing.T) {
+	c := &Complex{}
+	c.NewSimplex(0, 1, 2)
+	c.SetField(Q)
+
+	cg := c.ChainGroup(1)
+	a := cg.Singleton(c.GetSimplex(0, 1)).Scale(big.NewRat(1, 3))
+
+	v := a.CoefficientVector()
+	if len(v) != cg.Rank() {
+		t.Fatalf("expected %d coefficients, got %d", cg.Rank(), len(v))
+	}
+
+	b := cg.ChainFromCoefficientVector(v)
+	if !b.Equals(a) {
+		t.Errorf("expected %v, got %v", a, b)
+	}
+	if x := b.Coefficient(c.GetSimplex(0, 1)); x.Cmp(big.NewRat(1, 3)) != 0 {
+		t.Errorf("expected coefficient 1/3, got %v", x)
+	}
+
+	if cg.ChainFromCoefficientVector(v[1:]) != nil {
+		t.Errorf("expected nil for a vector of the wrong length")
+	}
+}
+
+func TestIndependentExtension(t *testing.T) {
+	column := func(xs ...int64) []*big.Rat {
+		col := []*big.Rat{}
+		for _, x := range xs {
+			col = append(col, big.NewRat(x, 1))
+		}
+		return col
+	}
+
+	span := [][]*big.Rat{column(1, 1, 0)}
+	cols := [][]*big.Rat{column(1, 1, 0), column(1, -1, 0), column(0, 1, 1), column(0, 0, 1)}
+
+	// (1, -1, 0) is congruent to the spanning column modulo 2, but not over Q
+	for _, tc := range []struct {
+		f    Field
+		kept []int
+	}{
+		{f: Z2, kept: []int{2, 3}},
+		{f: Q, kept: []int{1, 2}},
+	} {
+		t.Run(tc.f.String(), func(tt *testing.T) {
+			kept := independentExtension(tc.f, span, cols)
+			if len(kept) != len(tc.kept) {
+				tt.Fatalf("expected columns %v to be kept, got %v", tc.kept, kept)
+			}
+			for i := range kept {
+				if kept[i] != tc.kept[i] {
+					tt.Fatalf("expected columns %v to be kept, got %v", tc.kept, kept)
+				}
+			}
+		})
+	}
+
+	if kept := independentExtension(Q, nil, nil); len(kept) != 0 {
+		t.Errorf("expected no columns to be kept, got %v", kept)
+	}
+}
@@ -0,0 +1,103 @@
+package comptop
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BirthFunc assigns a birth time to each Simplex of a Complex.
+type BirthFunc func(*Simplex) float64
+
+// Filtration is a Complex whose simplices are totally ordered by the time at which they are born.
+// Every Simplex is born no earlier than each of its faces, so every initial segment of a Filtration is a sub-Complex.
+// Simplices born at the same time are ordered by dimension, then by Index.
+//
+// More info: https://en.wikipedia.org/wiki/Filtration_(mathematics)
+type Filtration struct {
+	complex *Complex
+
+	simplices []*Simplex
+	births    map[*Simplex]float64
+	positions map[*Simplex]int
+
+	pairs map[Dim][]*PersistenceInterval
+}
+
+// Filtration returns the Filtration of c whose birth times are given by b.
+// If b assigns a Simplex an earlier time than one of its faces, the Simplex is instead born with its latest face.
+func (c *Complex) Filtration(b BirthFunc) *Filtration {
+	f := &Filtration{
+		complex:   c,
+		simplices: []*Simplex{},
+		births:    map[*Simplex]float64{},
+		positions: map[*Simplex]int{},
+	}
+
+	for d := Dim(0); d <= c.dim; d++ {
+		for _, smplx := range c.GetdSimplices(d) {
+			t := b(smplx)
+			if d > 0 {
+				for _, face := range smplx.Faces(d - 1).Slice() {
+					if ft := f.births[face]; ft > t {
+						t = ft
+					}
+				}
+			}
+
+			f.births[smplx] = t
+			f.simplices = append(f.simplices, smplx)
+		}
+	}
+
+	sort.Slice(f.simplices, func(i, j int) bool {
+		a, b := f.simplices[i], f.simplices[j]
+		if ta, tb := f.births[a], f.births[b]; ta != tb {
+			return ta < tb
+		}
+		if a.Dim() != b.Dim() {
+			return a.Dim() < b.Dim()
+		}
+		return a.index < b.index
+	})
+
+	for idx, smplx := range f.simplices {
+		f.positions[smplx] = idx
+	}
+
+	return f
+}
+
+func (f *Filtration) String() string {
+	s := "Filtration{"
+
+	for _, smplx := range f.simplices {
+		s += fmt.Sprintf("%v: %v, ", f.births[smplx], smplx)
+	}
+
+	s += "}"
+
+	return s
+}
+
+// Complex returns the Complex being filtered.
+func (f *Filtration) Complex() *Complex {
+	return f.complex
+}
+
+// Simplices returns a copy of the simplices of the Filtration, in the order they are born.
+func (f *Filtration) Simplices() []*Simplex {
+	simplices := make([]*Simplex, len(f.simplices))
+	copy(simplices, f.simplices)
+
+	return simplices
+}
+
+// Birth returns the time at which s is born.
+func (f *Filtration) Birth(s *Simplex) float64 {
+	return f.births[s]
+}
+
+// Len returns the number of simplices in the Filtration.
+func (f *Filtration) Len() int {
+	return len(f.simplices)
+}
@@ -0,0 +1,290 @@
+package comptop
+
+import (
+	"math/big"
+	"math/bits"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// gf2Matrix is a matrix with entries in Z_2.
+// Each column is packed into a slice of 64-bit words so that adding one column to another is a word-wise XOR.
+type gf2Matrix struct {
+	rows, cols int
+	words      int
+
+	data [][]uint64
+}
+
+func newGF2Matrix(m, n int) *gf2Matrix {
+	a := &gf2Matrix{
+		rows:  m,
+		cols:  n,
+		words: (m + 63) / 64,
+		data:  make([][]uint64, n),
+	}
+
+	for col := range a.data {
+		a.data[col] = make([]uint64, a.words)
+	}
+
+	return a
+}
+
+func newGF2Identity(n int) *gf2Matrix {
+	a := newGF2Matrix(n, n)
+	for i := 0; i < n; i++ {
+		a.set(i, i)
+	}
+
+	return a
+}
+
+// gf2MatrixFrom returns the matrix over Z_2 obtained by reducing the entries of m modulo 2.
+func gf2MatrixFrom(m mat.Matrix) *gf2Matrix {
+	rows, cols := m.Dims()
+	a := newGF2Matrix(rows, cols)
+
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			if int(m.At(row, col))%2 != 0 {
+				a.set(row, col)
+			}
+		}
+	}
+
+	return a
+}
+
+func (a *gf2Matrix) at(row, col int) bool {
+	return a.data[col][row/64]&(1<<uint(row%64)) != 0
+}
+
+func (a *gf2Matrix) set(row, col int) {
+	a.data[col][row/64] |= 1 << uint(row%64)
+}
+
+func (a *gf2Matrix) flip(row, col int) {
+	a.data[col][row/64] ^= 1 << uint(row%64)
+}
+
+// addCol adds column src to column dst ( dst -> src+dst ).
+func (a *gf2Matrix) addCol(src, dst int) {
+	s, d := a.data[src], a.data[dst]
+	for w := range d {
+		d[w] ^= s[w]
+	}
+}
+
+// addColFrom adds column src of b to column dst of a; a and b must have the same number of rows.
+func (a *gf2Matrix) addColFrom(b *gf2Matrix, src, dst int) {
+	s, d := b.data[src], a.data[dst]
+	for w := range d {
+		d[w] ^= s[w]
+	}
+}
+
+func (a *gf2Matrix) swapCols(i, j int) {
+	a.data[i], a.data[j] = a.data[j], a.data[i]
+}
+
+// low returns the largest row with a 1 in column col; returns -1 if the column is zero.
+func (a *gf2Matrix) low(col int) int {
+	c := a.data[col]
+	for w := len(c) - 1; w >= 0; w-- {
+		if c[w] != 0 {
+			return 64*w + 63 - bits.LeadingZeros64(c[w])
+		}
+	}
+
+	return -1
+}
+
+// ones returns the rows with a 1 in column col, in increasing order.
+func (a *gf2Matrix) ones(col int) []int {
+	rows := []int{}
+	for w, word := range a.data[col] {
+		for word != 0 {
+			rows = append(rows, 64*w+bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+
+	return rows
+}
+
+// column returns column col of a as a vector of 0s and 1s.
+func (a *gf2Matrix) column(col int) []*big.Rat {
+	entries := make([]big.Rat, a.rows)
+	v := make([]*big.Rat, a.rows)
+	for row := range v {
+		v[row] = &entries[row]
+	}
+	for _, row := range a.ones(col) {
+		v[row].SetInt64(1)
+	}
+
+	return v
+}
+
+func (a *gf2Matrix) copy() *gf2Matrix {
+	b := newGF2Matrix(a.rows, a.cols)
+	for col := range a.data {
+		copy(b.data[col], a.data[col])
+	}
+
+	return b
+}
+
+// transpose returns the transpose of a.
+func (a *gf2Matrix) transpose() *gf2Matrix {
+	t := newGF2Matrix(a.cols, a.rows)
+	for col := 0; col < a.cols; col++ {
+		for _, row := range a.ones(col) {
+			t.set(col, row)
+		}
+	}
+
+	return t
+}
+
+// columnReduce adds columns of a, left to right, to the columns after them until no two non-zero columns share their lowest 1.
+// a is reduced in place to R = D * V, where D is a before the reduction; V is returned.
+//
+// More info: 'Computational Topology: An Introduction' by Edelsbrunner & Harer, pg 153.
+func (a *gf2Matrix) columnReduce() *gf2Matrix {
+	v := newGF2Identity(a.cols)
+
+	pivots := make([]int, a.rows)
+	for row := range pivots {
+		pivots[row] = -1
+	}
+
+	for col := 0; col < a.cols; col++ {
+		for low := a.low(col); low >= 0 && pivots[low] >= 0; low = a.low(col) {
+			k := pivots[low]
+			a.addCol(k, col)
+			v.addCol(k, col)
+		}
+
+		if low := a.low(col); low >= 0 {
+			pivots[low] = col
+		}
+	}
+
+	return v
+}
+
+// independentColumns returns, in increasing order, the columns of a which are not in the span of the columns before them.
+func (a *gf2Matrix) independentColumns() []int {
+	work := a.copy()
+	pivots := map[int]int{}
+	cols := []int{}
+
+	for col := 0; col < work.cols; col++ {
+		for low := work.low(col); low >= 0; low = work.low(col) {
+			k, exists := pivots[low]
+			if !exists {
+				pivots[low] = col
+				cols = append(cols, col)
+				break
+			}
+			work.addCol(k, col)
+		}
+	}
+
+	return cols
+}
+
+// inverse returns the inverse of the square, invertible matrix a, computed with Gauss-Jordan elimination on columns.
+func (a *gf2Matrix) inverse() *gf2Matrix {
+	n := a.cols
+	work := a.copy()
+	inv := newGF2Identity(n)
+
+	// Column operations on a are right multiplications, so when a has been reduced to the identity, inv = a^-1.
+	for row := 0; row < n; row++ {
+		pivot := -1
+		for col := row; col < n; col++ {
+			if work.at(row, col) {
+				pivot = col
+				break
+			}
+		}
+		if pivot < 0 {
+			return nil
+		}
+
+		work.swapCols(row, pivot)
+		inv.swapCols(row, pivot)
+
+		for col := 0; col < n; col++ {
+			if col != row && work.at(row, col) {
+				work.addCol(row, col)
+				inv.addCol(row, col)
+			}
+		}
+	}
+
+	return inv
+}
+
+// dense returns a as a gonum matrix of 0s and 1s.
+func (a *gf2Matrix) dense() *mat.Dense {
+	d := mat.NewDense(a.rows, a.cols, nil)
+	for col := 0; col < a.cols; col++ {
+		for _, row := range a.ones(col) {
+			d.Set(row, col, 1.0)
+		}
+	}
+
+	return d
+}
+
+// gf2Reducer incrementally keeps a set of vectors over Z_2 reduced so that no two share their lowest 1.
+type gf2Reducer struct {
+	words  int
+	pivots map[int][]uint64
+}
+
+func newGF2Reducer(rows int) *gf2Reducer {
+	return &gf2Reducer{
+		words:  (rows + 63) / 64,
+		pivots: map[int][]uint64{},
+	}
+}
+
+// vector returns a zero vector of the right length.
+func (r *gf2Reducer) vector() []uint64 {
+	return make([]uint64, r.words)
+}
+
+// add reduces a copy of v against the vectors already added and keeps it if it is independent of them.
+// It returns true if v was independent.
+func (r *gf2Reducer) add(v []uint64) bool {
+	v = append([]uint64(nil), v...)
+
+	for {
+		low := -1
+		for w := len(v) - 1; w >= 0; w-- {
+			if v[w] != 0 {
+				low = 64*w + 63 - bits.LeadingZeros64(v[w])
+				break
+			}
+		}
+
+		if low < 0 {
+			return false
+		}
+
+		pivot, exists := r.pivots[low]
+		if !exists {
+			r.pivots[low] = v
+			return true
+		}
+
+		for w := range v {
+			v[w] ^= pivot[w]
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package comptop
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestGF2Matrix_inverse(t *testing.T) {
+	a := gf2MatrixFrom(mat.NewDense(3, 3, []float64{
+		1, 1, 0,
+		0, 1, 1,
+		0, 0, 1,
+	}))
+
+	inv := a.inverse()
+	if inv == nil {
+		t.Fatal("expected matrix to be invertible")
+	}
+
+	p := mat.NewDense(3, 3, nil)
+	p.Mul(a.dense(), inv.dense())
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			p.Set(row, col, float64(int(p.At(row, col))%2))
+		}
+	}
+
+	if !mat.Equal(p, gf2MatrixFrom(mat.NewDense(3, 3, []float64{
+		1, 0, 0,
+		0, 1, 0,
+		0, 0, 1,
+	})).dense()) {
+		t.Errorf("invalid inverse:\n%v", mat.Formatted(inv.dense()))
+	}
+
+	if low := a.low(1); low != 1 {
+		t.Errorf("expected lowest 1 of column 1 to be in row 1, got %d", low)
+	}
+
+	a.addCol(0, 1)
+	if low := a.low(1); low != 1 || a.at(0, 1) {
+		t.Errorf("invalid column addition:\n%v", mat.Formatted(a.dense()))
+	}
+}
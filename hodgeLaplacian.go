@@ -0,0 +1,251 @@
+package comptop
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// harmonicTolerance is the largest eigenvalue, relative to the largest eigenvalue of the Laplacian, which is treated as 0.
+const harmonicTolerance = 1e-9
+
+// UpLaplacian returns the matrix of the up Laplacian L^up_p = B_{p+1} * B_{p+1}^* acting on the real p-chains of cg,
+// where B_{p+1} is the oriented boundary matrix of the ChainGroup one dimension higher and B_{p+1}^* its adjoint.
+// Each Simplex s is given the inner product <s, s> = w(s); if w is nil, every Simplex has weight 1 and the matrix is symmetric.
+// Rows and columns follow the basis of cg.
+// Returns nil if cg has no simplices.
+//
+// More info: https://en.wikipedia.org/wiki/Laplacian_matrix#Generalizations_and_extensions
+func (cg *ChainGroup) UpLaplacian(w SimplexWeight) *mat.Dense {
+	return cg.laplacian(w, true, false)
+}
+
+// DownLaplacian returns the matrix of the down Laplacian L^down_p = B_p^* * B_p acting on the real p-chains of cg,
+// where B_p is the oriented boundary matrix of cg and B_p^* its adjoint.
+// Weights are used as in UpLaplacian.
+// Returns nil if cg has no simplices.
+func (cg *ChainGroup) DownLaplacian(w SimplexWeight) *mat.Dense {
+	return cg.laplacian(w, false, true)
+}
+
+// HodgeLaplacian returns the matrix of the Hodge Laplacian L_p = L^up_p + L^down_p acting on the real p-chains of cg.
+// Weights are used as in UpLaplacian; for p = 0 and unit weights this is the graph Laplacian of the 1-skeleton.
+// The dimension of the kernel of L_p is the p^th Betti number over the reals (the unreduced one when p = 0).
+// Returns nil if cg has no simplices.
+//
+// More info: 'Spectra of combinatorial Laplace operators on simplicial complexes' by Horak & Jost.
+func (cg *ChainGroup) HodgeLaplacian(w SimplexWeight) *mat.Dense {
+	return cg.laplacian(w, true, true)
+}
+
+// LaplacianSpectrum returns the eigenvalues of the Hodge Laplacian of cg in ascending order.
+// The Hodge Laplacian is self-adjoint with respect to the weighted inner product, so its eigenvalues are real and not negative.
+// Returns nil if cg has no simplices; an error is returned if the eigendecomposition fails to converge.
+func (cg *ChainGroup) LaplacianSpectrum(w SimplexWeight) ([]float64, error) {
+	sym := cg.symmetricLaplacian(w, true, true)
+	if sym == nil {
+		return nil, nil
+	}
+
+	var es mat.EigenSym
+	if !es.Factorize(sym, false) {
+		return nil, fmt.Errorf("comptop: LaplacianSpectrum: the eigendecomposition of the Hodge Laplacian in dimension %d failed", cg.dim)
+	}
+
+	return es.Values(nil), nil
+}
+
+// HarmonicBasis returns a matrix whose columns form a basis of the harmonic p-chains of cg, the kernel of its Hodge Laplacian.
+// The columns are orthonormal with respect to the weighted inner product and their entries follow the basis of cg.
+// Each harmonic chain is a real cycle orthogonal to every boundary, so the columns represent a basis of the real homology in dimension p.
+// Returns nil if there are no harmonic chains; an error is returned if the eigendecomposition of the Hodge Laplacian fails to converge.
+func (cg *ChainGroup) HarmonicBasis(w SimplexWeight) (*mat.Dense, error) {
+	h, err := cg.harmonicBasis(w)
+	if h == nil {
+		return nil, err
+	}
+
+	weights := cg.simplexWeights(w)
+	h.Apply(func(i, j int, v float64) float64 {
+		return v / math.Sqrt(weights[i])
+	}, h)
+
+	return h, nil
+}
+
+// HarmonicRepresentative returns the harmonic chain homologous to the cycle c over the reals, as a vector following the basis of the ChainGroup.
+// It is the orthogonal projection of c, with respect to the weighted inner product, onto the harmonic chains,
+// which is the unique representative of the class of c with the smallest weighted norm.
+// The coefficients of c are read as rational numbers, so over Z_2 or Z_p the orientations of its simplices are those of its coefficients.
+// Returns nil if c is not a cycle of the ChainGroup; an error is returned if the eigendecomposition of the Hodge Laplacian fails to converge.
+func (hg *HomologyGroup) HarmonicRepresentative(c *Chain, w SimplexWeight) (*mat.VecDense, error) {
+	cg := hg.chainGroup
+	if c == nil || c.chaingroup != cg || !c.IsCycle() {
+		return nil, nil
+	}
+
+	weights := cg.simplexWeights(w)
+	x := mat.NewVecDense(len(weights), nil)
+	for row, a := range cg.column(c) {
+		v, _ := a.Float64()
+		x.SetVec(row, v*math.Sqrt(weights[row]))
+	}
+
+	h, err := cg.harmonicBasis(w)
+	if err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return mat.NewVecDense(len(weights), nil), nil
+	}
+
+	_, k := h.Dims()
+	coords := mat.NewVecDense(k, nil)
+	coords.MulVec(h.T(), x)
+
+	rep := mat.NewVecDense(len(weights), nil)
+	rep.MulVec(h, coords)
+	for row := range weights {
+		rep.SetVec(row, rep.AtVec(row)/math.Sqrt(weights[row]))
+	}
+
+	return rep, nil
+}
+
+// HodgeLaplacians returns the Hodge Laplacians of c, one per dimension.
+func (c *Complex) HodgeLaplacians(w SimplexWeight) []*mat.Dense {
+	laplacians := []*mat.Dense{}
+
+	for d := Dim(0); d <= c.dim; d++ {
+		laplacians = append(laplacians, c.ChainGroup(d).HodgeLaplacian(w))
+	}
+
+	return laplacians
+}
+
+// LaplacianSpectra returns the spectra of the Hodge Laplacians of c, one per dimension.
+// An error is returned if any of them can't be computed (see LaplacianSpectrum).
+func (c *Complex) LaplacianSpectra(w SimplexWeight) ([][]float64, error) {
+	spectra := [][]float64{}
+
+	for d := Dim(0); d <= c.dim; d++ {
+		spectrum, err := c.ChainGroup(d).LaplacianSpectrum(w)
+		if err != nil {
+			return nil, err
+		}
+		spectra = append(spectra, spectrum)
+	}
+
+	return spectra, nil
+}
+
+// laplacian returns the up, down or Hodge Laplacian of cg, recovered from its symmetric form L' as W^{-1/2} * L' * W^{1/2}.
+func (cg *ChainGroup) laplacian(w SimplexWeight, up, down bool) *mat.Dense {
+	sym := cg.symmetricLaplacian(w, up, down)
+	if sym == nil {
+		return nil
+	}
+
+	weights := cg.simplexWeights(w)
+
+	l := mat.NewDense(len(weights), len(weights), nil)
+	l.Apply(func(i, j int, v float64) float64 {
+		return sym.At(i, j) * math.Sqrt(weights[j]/weights[i])
+	}, l)
+
+	return l
+}
+
+// symmetricLaplacian returns the symmetric form W^{1/2} * L * W^{-1/2} of the up, down or Hodge Laplacian L of cg, where W is the diagonal matrix of weights.
+// It is built as A * A^T + C^T * C with A and C the scaled boundary matrices of the ChainGroup one dimension higher and of cg.
+func (cg *ChainGroup) symmetricLaplacian(w SimplexWeight, up, down bool) *mat.SymDense {
+	n := cg.Rank()
+	if n == 0 {
+		return nil
+	}
+
+	sym := mat.NewSymDense(n, nil)
+
+	if higherGroup := cg.complex.ChainGroup(cg.dim + 1); up && higherGroup != nil {
+		if a := higherGroup.scaledBoundaryMatrix(w); a != nil {
+			var upper mat.SymDense
+			upper.SymOuterK(1, a)
+			sym.AddSym(sym, &upper)
+		}
+	}
+
+	if c := cg.scaledBoundaryMatrix(w); down && c != nil {
+		var lower mat.SymDense
+		lower.SymOuterK(1, c.T())
+		sym.AddSym(sym, &lower)
+	}
+
+	return sym
+}
+
+// scaledBoundaryMatrix returns W_{p-1}^{1/2} * B_p * W_p^{-1/2}, where B_p is the oriented boundary matrix of cg.
+// Returns nil if cg has no boundary matrix.
+func (cg *ChainGroup) scaledBoundaryMatrix(w SimplexWeight) *mat.Dense {
+	b := cg.SignedBoundaryMatrix()
+	if b == nil {
+		return nil
+	}
+
+	rowWeights := cg.lowerGroup().simplexWeights(w)
+	colWeights := cg.simplexWeights(w)
+
+	var scaled mat.Dense
+	scaled.Apply(func(i, j int, v float64) float64 {
+		return v * math.Sqrt(rowWeights[i]/colWeights[j])
+	}, b)
+
+	return &scaled
+}
+
+// harmonicBasis returns an orthonormal basis of the kernel of the symmetric form of the Hodge Laplacian of cg; returns nil if the kernel is trivial.
+func (cg *ChainGroup) harmonicBasis(w SimplexWeight) (*mat.Dense, error) {
+	sym := cg.symmetricLaplacian(w, true, true)
+	if sym == nil {
+		return nil, nil
+	}
+
+	var es mat.EigenSym
+	if !es.Factorize(sym, true) {
+		return nil, fmt.Errorf("comptop: HarmonicBasis: the eigendecomposition of the Hodge Laplacian in dimension %d failed", cg.dim)
+	}
+
+	values := es.Values(nil)
+	var vectors mat.Dense
+	es.VectorsTo(&vectors)
+
+	tol := harmonicTolerance * math.Max(1, values[len(values)-1])
+	k := 0
+	for k < len(values) && values[k] <= tol {
+		k++
+	}
+
+	if k == 0 {
+		return nil, nil
+	}
+
+	h := mat.NewDense(len(values), k, nil)
+	h.Copy(vectors.Slice(0, len(values), 0, k))
+
+	return h, nil
+}
+
+// simplexWeights returns the weights w gives the simplices of cg, following its basis; every weight is 1 if w is nil.
+func (cg *ChainGroup) simplexWeights(w SimplexWeight) []float64 {
+	cg.sortIdxs()
+
+	weights := make([]float64, len(cg.idxs))
+	for row, idx := range cg.idxs {
+		weights[row] = 1
+		if w != nil {
+			weights[row] = w(cg.Simplex(idx))
+		}
+	}
+
+	return weights
+}
@@ -0,0 +1,117 @@
+package comptop
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestChainGroup_HodgeLaplacian(t *testing.T) {
+	t.Run("circle", func(tt *testing.T) {
+		c := &Complex{}
+		c.NewSimplices([]Base{{0, 1}, {1, 2}, {0, 2}}...)
+
+		// The graph Laplacian of a triangle and the down Laplacian of its edges share the spectrum 0, 3, 3
+		expected := []float64{0, 3, 3}
+		spectra, err := c.LaplacianSpectra(nil)
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		for _, spectrum := range spectra {
+			if len(spectrum) != len(expected) {
+				tt.Fatalf("expected %d eigenvalues, got %v", len(expected), spectrum)
+			}
+			for idx, v := range spectrum {
+				if math.Abs(v-expected[idx]) > 1e-9 {
+					tt.Errorf("expected spectrum %v, got %v", expected, spectrum)
+				}
+			}
+		}
+
+		expectedL0 := mat.NewDense(3, 3, []float64{
+			2, -1, -1,
+			-1, 2, -1,
+			-1, -1, 2,
+		})
+		if l0 := c.ChainGroup(0).HodgeLaplacian(nil); !mat.EqualApprox(l0, expectedL0, 1e-9) {
+			tt.Errorf("expected L_0 =\n%v\ngot\n%v", mat.Formatted(expectedL0), mat.Formatted(l0))
+		}
+
+		if up := c.ChainGroup(1).UpLaplacian(nil); mat.Norm(up, 1) != 0 {
+			tt.Errorf("expected a zero up Laplacian without triangles, got\n%v", mat.Formatted(up))
+		}
+	})
+
+	t.Run("torus", func(tt *testing.T) {
+		c := &Complex{}
+		c.SetField(Q)
+		c.NewSimplices([]Base{
+			{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5},
+			{3, 4, 6}, {4, 6, 7}, {4, 5, 7}, {5, 7, 8}, {3, 5, 8}, {3, 6, 8},
+			{0, 6, 7}, {0, 1, 7}, {1, 7, 8}, {1, 2, 8}, {2, 6, 8}, {0, 2, 6},
+		}...)
+
+		weight := func(s *Simplex) float64 { return float64(1 + s.base[0]%3) }
+
+		for _, w := range []SimplexWeight{nil, weight} {
+			expectedBN := []int{1, 2, 1}
+			for d, bn := range expectedBN {
+				cg := c.ChainGroup(Dim(d))
+
+				h, err := cg.HarmonicBasis(w)
+				if err != nil {
+					tt.Fatalf("unexpected error: %v", err)
+				}
+				if h == nil {
+					tt.Fatalf("expected harmonic %d-chains", d)
+				}
+				if _, k := h.Dims(); k != bn {
+					tt.Errorf("expected %d harmonic %d-chains, got %d", bn, d, k)
+				}
+
+				// L_p is self-adjoint with respect to the weights, so W * L_p is symmetric
+				l := cg.HodgeLaplacian(w)
+				weights := cg.simplexWeights(w)
+				var wl mat.Dense
+				wl.Apply(func(i, j int, v float64) float64 { return weights[i] * v }, l)
+				if !mat.EqualApprox(&wl, wl.T(), 1e-9) {
+					tt.Errorf("expected W * L_%d to be symmetric", d)
+				}
+			}
+
+			edges := c.ChainGroup(1)
+			loop := edges.NewChainFromCoefficients(map[*Simplex]*big.Rat{
+				c.GetSimplex(0, 3): big.NewRat(1, 1),
+				c.GetSimplex(3, 6): big.NewRat(1, 1),
+				c.GetSimplex(0, 6): big.NewRat(-1, 1),
+			})
+			pushed := loop.Add(c.ChainGroup(2).NewChainFromSimplices(c.GetSimplex(3, 6, 8)).SignedBoundary())
+
+			hg := edges.HomologyGroup()
+			a, errA := hg.HarmonicRepresentative(loop, w)
+			b, errB := hg.HarmonicRepresentative(pushed, w)
+			if errA != nil || errB != nil {
+				tt.Fatalf("unexpected errors: %v, %v", errA, errB)
+			}
+			if !mat.EqualApprox(a, b, 1e-9) {
+				tt.Errorf("expected homologous cycles to share a harmonic representative, got\n%v\nand\n%v", mat.Formatted(a), mat.Formatted(b))
+			}
+			if mat.Norm(a, 2) == 0 {
+				tt.Error("expected a non-zero harmonic representative for a loop around the torus")
+			}
+
+			var la mat.VecDense
+			la.MulVec(edges.HodgeLaplacian(w), a)
+			if mat.Norm(&la, 2) > 1e-9 {
+				tt.Errorf("expected the representative to be harmonic, L_1 * h = %v", mat.Formatted(&la))
+			}
+
+			boundary := c.ChainGroup(2).NewChainFromSimplices(c.GetSimplex(0, 1, 3)).SignedBoundary()
+			if rep, _ := hg.HarmonicRepresentative(boundary, w); mat.Norm(rep, 2) > 1e-9 {
+				tt.Errorf("expected a boundary to have a zero harmonic representative, got %v", mat.Formatted(rep))
+			}
+		}
+	})
+}
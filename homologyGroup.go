@@ -1,7 +1,7 @@
 package comptop
 
 import (
-	"gonum.org/v1/gonum/mat"
+	"math/big"
 )
 
 // CycleGroup Z_p is a subgroup of the ChainGroup C_p of the same dimension p.
@@ -22,15 +22,8 @@ func (cg *ChainGroup) CycleGroup() *CycleGroup {
 		basis:      []*Chain{},
 	}
 
-	bm := cg.BoundaryMap()
-	v := bm.V()
-	l := bm.SmithNormalDiagonalLength()
-	rank := cg.Rank()
-
-	for i := l; i < rank; i++ {
-		col := mat.Col(nil, i, v)
-		chain := cg.ChainFromVector(Vector(mat.NewDense(rank, 1, col)))
-		cg.cg.basis = append(cg.cg.basis, chain)
+	for _, col := range cg.BoundaryMap().kernelBasis() {
+		cg.cg.basis = append(cg.cg.basis, cg.chainFromColumn(col))
 	}
 
 	return cg.cg
@@ -56,6 +49,7 @@ type BoundaryGroup struct {
 
 // BoundaryGroup B_p is a subgroup of the CycleGroup Z_p of the same dimension p.
 // A boundary group B_p consists of all cycles in the cycle group Z_p which are the boundary of a chain in C_{p+1}.
+// In the top dimension there are no (p+1)-chains and B_p is trivial, so the fundamental class of a closed manifold such as the sphere survives in the HomologyGroup.
 func (cg *ChainGroup) BoundaryGroup() *BoundaryGroup {
 	if cg.bg != nil {
 		return cg.bg
@@ -66,27 +60,18 @@ func (cg *ChainGroup) BoundaryGroup() *BoundaryGroup {
 		basis:      []*Chain{},
 	}
 
+	// There are no boundaries in the top dimension
 	higherGroup := cg.complex.ChainGroup(cg.Dim() + 1)
-	var bm *BoundaryMap
-	if higherGroup != nil {
-		bm = higherGroup.BoundaryMap()
-	} else {
-		data := make([]float64, cg.Rank())
-		for i := 0; i < cg.Rank(); i++ {
-			data[i] = 1.0
-		}
-		bm = &BoundaryMap{
-			mat: mat.NewDense(cg.Rank(), 1, data),
-		}
+	if higherGroup == nil {
+		return cg.bg
+	}
+	bm := higherGroup.BoundaryMap()
+	if bm == nil {
+		return cg.bg
 	}
-	ui := bm.UInverse()
-	l := bm.SmithNormalDiagonalLength()
-	rank := cg.Rank()
 
-	for i := 0; i < l; i++ {
-		col := mat.Col(nil, i, ui)
-		chain := cg.ChainFromVector(Vector(mat.NewDense(rank, 1, col)))
-		cg.bg.basis = append(cg.bg.basis, chain)
+	for _, col := range bm.imageBasis() {
+		cg.bg.basis = append(cg.bg.basis, cg.chainFromColumn(col))
 	}
 
 	return cg.bg
@@ -130,43 +115,57 @@ func (cg *ChainGroup) HomologyGroup() *HomologyGroup {
 
 }
 
+// Field returns the field of coefficients the homology group is computed over.
+func (hg *HomologyGroup) Field() Field {
+	return hg.chainGroup.Field()
+}
+
+// Basis returns cycles whose classes form a basis for the homology group.
+// Independence from the boundary group is tested exactly over the Field, as by MinimalBasis.
+// If the Complex is set to use Morse reduction (see SetMorseReduction), the basis is computed on its MorseComplex instead.
 func (hg *HomologyGroup) Basis() []*Chain {
 	if hg.basis != nil {
 		return hg.basis
 	}
 
-	cg := hg.chainGroup
-
-	z := cg.CycleGroup()
-	zBasis := z.Basis()
-	b := cg.BoundaryGroup()
+	if c := hg.chainGroup.complex; c.morse {
+		hg.basis = c.morseComplex().HomologyBasis(hg.chainGroup.dim)
+		return hg.basis
+	}
 
-	m := mat.NewDense(cg.Rank(), z.Rank(), nil)
+	hg.basis = hg.extendedBoundaryBasis()
 
-	for idx, chain := range b.Basis() {
-		col := mat.Col(nil, 0, mat.Matrix(chain.Vector()))
-		m.SetCol(idx, col)
-	}
+	return hg.basis
+}
 
-	cCombos := chainCombinations(z.Rank()-b.Rank(), zBasis)
+// extendedBoundaryBasis greedily extends the basis of the boundary group by cycles, in the order of the basis of the cycle group,
+// keeping the cycles which are independent of the ones before them; the kept cycles form a basis for the homology group.
+func (hg *HomologyGroup) extendedBoundaryBasis() []*Chain {
+	cg := hg.chainGroup
+	z := cg.CycleGroup().Basis()
+	b := cg.BoundaryGroup().Basis()
 
-	for _, combo := range cCombos {
-		for idx, chain := range combo {
-			col := mat.Col(nil, 0, mat.Matrix(chain.Vector()))
-			m.SetCol(b.Rank()+idx, col)
-		}
+	span := [][]*big.Rat{}
+	for _, chain := range b {
+		span = append(span, cg.column(chain))
+	}
+	cols := [][]*big.Rat{}
+	for _, chain := range z {
+		cols = append(cols, cg.column(chain))
+	}
 
-		if isLI(m) {
-			hg.basis = combo
-			return combo
-		}
+	basis := []*Chain{}
+	for _, j := range independentExtension(cg.Field(), span, cols) {
+		basis = append(basis, z[j])
 	}
 
-	return nil
+	return basis
 }
 
 // MinimalBasis computes a basis for the homology group that is minimal with respect to Hamming weight + length of the interesection of the chains in the basis.
 // The idea is to **try** to find the smallest cycles that cycle around the holes in the most linearly independent way.
+// Candidate bases are checked for independence from the boundary group in the same exact way as Basis, which is returned if none of them qualifies.
+// For a basis of the first homology group which is guaranteed to be shortest, see ShortestBasis.
 func (hg *HomologyGroup) MinimalBasis() []*Chain {
 	if hg.basis != nil && hg.minimal {
 		return hg.basis
@@ -175,17 +174,9 @@ func (hg *HomologyGroup) MinimalBasis() []*Chain {
 	cg := hg.chainGroup
 
 	z := cg.CycleGroup()
-	zBasis := z.Basis()
 	b := cg.BoundaryGroup()
 
-	m := mat.NewDense(cg.Rank(), z.Rank(), nil)
-
-	for idx, chain := range b.Basis() {
-		col := mat.Col(nil, 0, mat.Matrix(chain.Vector()))
-		m.SetCol(idx, col)
-	}
-
-	cCombos := chainCombinations(z.Rank()-b.Rank(), zBasis)
+	cCombos := chainCombinations(z.Rank()-b.Rank(), z.Basis())
 
 	var (
 		minCombo  []*Chain
@@ -195,26 +186,46 @@ func (hg *HomologyGroup) MinimalBasis() []*Chain {
 	for _, combo := range cCombos {
 		var (
 			intersection *Chain
-			w            int
+			ww           int
 		)
-		for idx, chain := range combo {
-			col := mat.Col(nil, 0, mat.Matrix(chain.Vector()))
-			m.SetCol(b.Rank()+idx, col)
+		for _, chain := range combo {
 			intersection = intersection.Intersection(chain)
-			w += hammingWeight(col)
+			ww += len(chain.simplices)
 		}
 
-		ww := intersection.Len() + w
+		if isBinary(cg.Field()) {
+			ww += intersection.Len()
+		}
 
 		if ww >= minWeight {
 			continue
 		}
 
-		if isLI(m) {
+		if hg.extendsBoundaryBasis(combo) {
 			minWeight = ww
 			minCombo = combo
 		}
 	}
 
+	if minCombo == nil {
+		return hg.Basis()
+	}
+
 	return minCombo
 }
+
+// extendsBoundaryBasis returns true if the chains together with the basis of the boundary group are linearly independent over the Field.
+func (hg *HomologyGroup) extendsBoundaryBasis(chains []*Chain) bool {
+	cg := hg.chainGroup
+	f := cg.Field()
+
+	cols := [][]*big.Rat{}
+	for _, chain := range cg.BoundaryGroup().Basis() {
+		cols = append(cols, cg.column(chain))
+	}
+	for _, chain := range chains {
+		cols = append(cols, cg.column(chain))
+	}
+
+	return fieldRank(f, cols...) == len(cols)
+}
@@ -0,0 +1,165 @@
+package comptop
+
+import (
+	"math/big"
+)
+
+// IsCycle returns true if c has a zero boundary.
+// Every 0-dimensional Chain is a cycle.
+//
+// More info: https://en.wikipedia.org/wiki/Simplicial_homology#Boundaries_and_cycles
+func (c *Chain) IsCycle() bool {
+	if c.dim == 0 || c.isCycle || len(c.simplices) == 0 {
+		return true
+	}
+
+	return c.Boundary().IsZero()
+}
+
+// IsBoundary returns true if c is the boundary of some Chain one dimension higher.
+// The zero Chain is always a boundary, even in the top dimension.
+func (c *Chain) IsBoundary() bool {
+	return c.IsZero() || c.BoundedBy() != nil
+}
+
+// BoundedBy returns a (p+1)-chain whose boundary is the p-chain c; returns nil if c is not a boundary.
+// The zero Chain is bounded by the zero Chain one dimension higher;
+// in the top dimension there are no (p+1)-chains, so nil is returned for every Chain (see IsBoundary for the zero Chain).
+//
+// The witness is found from the factorization of the boundary matrix cached by the BoundaryMap of the ChainGroup one dimension higher:
+// over Z_2, c is reduced against the columns of R = D * V and the columns of V used along the way add up to the witness;
+// over other fields, c is written in the basis given by U and pulled back through V.
+func (c *Chain) BoundedBy() *Chain {
+	group := c.chaingroup
+	higherGroup := group.complex.ChainGroup(group.dim + 1)
+
+	if higherGroup == nil {
+		return nil
+	}
+
+	bm := higherGroup.BoundaryMap()
+	if bm == nil {
+		if c.IsZero() {
+			return higherGroup.zero
+		}
+		return nil
+	}
+
+	if isBinary(bm.field) {
+		return higherGroup.boundedByZ2(bm, c)
+	}
+
+	return higherGroup.boundedByField(bm, c)
+}
+
+// boundedByZ2 solves D x = c over Z_2, where D is the boundary matrix of cg, using the column reduction R = D * V.
+func (cg *ChainGroup) boundedByZ2(bm *BoundaryMap, c *Chain) *Chain {
+	bm.reduceZ2()
+
+	lowerGroup := cg.lowerGroup()
+	lowerGroup.sortIdxs()
+	rows := make(map[Index]int, len(lowerGroup.idxs))
+	for row, idx := range lowerGroup.idxs {
+		rows[idx] = row
+	}
+
+	pivots := map[int]int{}
+	for col := 0; col < bm.rank; col++ {
+		pivots[bm.r.low(col)] = col
+	}
+
+	y := newGF2Matrix(bm.r.rows, 1)
+	for _, smplx := range c.simplices {
+		y.flip(rows[smplx.index], 0)
+	}
+
+	x := newGF2Matrix(bm.rv.rows, 1)
+	for low := y.low(0); low >= 0; low = y.low(0) {
+		col, exists := pivots[low]
+		if !exists {
+			return nil
+		}
+
+		y.addColFrom(bm.r, col, 0)
+		x.addColFrom(bm.rv, col, 0)
+	}
+
+	return cg.chainFromGF2Column(x, 0)
+}
+
+// boundedByField solves D x = c over the Field of cg using the Smith normal factorization S = U * D * V:
+// with y = U * c, a solution exists if and only if y vanishes past the diagonal of S, in which case x = V * y.
+func (cg *ChainGroup) boundedByField(bm *BoundaryMap, c *Chain) *Chain {
+	bm.reduce()
+	f := bm.field
+
+	lowerGroup := cg.lowerGroup()
+	col := lowerGroup.column(c)
+
+	m, _ := bm.fu.dims()
+	_, n := bm.fv.dims()
+	l := bm.SmithNormalDiagonalLength()
+
+	y := make([]*big.Rat, m)
+	for row := 0; row < m; row++ {
+		y[row] = new(big.Rat)
+		for k, a := range bm.fu[row] {
+			if a.Sign() != 0 && col[k].Sign() != 0 {
+				y[row] = fieldAdd(f, y[row], fieldMul(f, a, col[k]))
+			}
+		}
+
+		if row >= l && y[row].Sign() != 0 {
+			return nil
+		}
+	}
+
+	x := make([]*big.Rat, n)
+	for row := 0; row < n; row++ {
+		x[row] = new(big.Rat)
+		for k := 0; k < l; k++ {
+			if a := bm.fv[row][k]; a.Sign() != 0 && y[k].Sign() != 0 {
+				x[row] = fieldAdd(f, x[row], fieldMul(f, a, y[k]))
+			}
+		}
+	}
+
+	return cg.chainFromColumn(x)
+}
+
+// IsHomologous returns true if c and a are cycles of the same ChainGroup whose difference is a boundary.
+func (c *Chain) IsHomologous(a *Chain) bool {
+	if a == nil || c.chaingroup != a.chaingroup || !c.IsCycle() || !a.IsCycle() {
+		return false
+	}
+
+	return c.Add(a.Scale(big.NewRat(-1, 1))).IsBoundary()
+}
+
+// Coordinates returns the coordinates of the homology class of the cycle c with respect to the classes of the cycles returned by Basis.
+// Coordinates returns nil if c is not a cycle of the same ChainGroup.
+// As H_0 is reduced, a 0-chain whose coefficients do not add up to zero, such as a single vertex, has no class in it:
+// Coordinates returns nil for it even though IsCycle holds.
+func (hg *HomologyGroup) Coordinates(c *Chain) []*big.Rat {
+	cg := hg.chainGroup
+	if c == nil || c.chaingroup != cg || !c.IsCycle() {
+		return nil
+	}
+
+	basis := hg.Basis()
+
+	cols := [][]*big.Rat{}
+	for _, chain := range basis {
+		cols = append(cols, cg.column(chain))
+	}
+	for _, chain := range cg.BoundaryGroup().Basis() {
+		cols = append(cols, cg.column(chain))
+	}
+
+	x, ok := fieldSolve(cg.Field(), cols, cg.column(c))
+	if !ok {
+		return nil
+	}
+
+	return x[:len(basis)]
+}
@@ -0,0 +1,122 @@
+package comptop
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestChain_BoundedBy(t *testing.T) {
+	for _, f := range []Field{Z2, Q} {
+		t.Run(f.String(), func(tt *testing.T) {
+			c := &Complex{}
+			c.SetField(f)
+			c.NewSimplices([]Base{
+				{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5},
+				{3, 4, 6}, {4, 6, 7}, {4, 5, 7}, {5, 7, 8}, {3, 5, 8}, {3, 6, 8},
+				{0, 6, 7}, {0, 1, 7}, {1, 7, 8}, {1, 2, 8}, {2, 6, 8}, {0, 2, 6},
+			}...)
+
+			edges := c.ChainGroup(1)
+
+			// The boundary of a patch of two triangles is bounded by the patch;
+			// it is rebuilt so that it isn't already known to be a cycle
+			patch := c.ChainGroup(2).NewChainFromSimplices(c.GetSimplex(0, 1, 3), c.GetSimplex(1, 3, 4))
+			boundary := edges.ChainFromCoefficientVector(patch.SignedBoundary().CoefficientVector())
+			if !boundary.IsCycle() || !boundary.IsBoundary() {
+				tt.Fatalf("expected %v to be a bounding cycle", boundary)
+			}
+
+			witness := boundary.BoundedBy()
+			if witness == nil {
+				tt.Fatal("expected a witness")
+			}
+			wb := witness.Boundary()
+			if !wb.Equals(boundary) {
+				tt.Errorf("expected the witness to bound %v, its boundary is %v", boundary, wb)
+			}
+
+			// A loop around the torus is a cycle which doesn't bound
+			loop := edges.NewChainFromCoefficients(map[*Simplex]*big.Rat{
+				c.GetSimplex(0, 3): big.NewRat(1, 1),
+				c.GetSimplex(3, 6): big.NewRat(1, 1),
+				c.GetSimplex(0, 6): big.NewRat(-1, 1),
+			})
+			if !loop.IsCycle() {
+				tt.Fatalf("expected %v to be a cycle", loop)
+			}
+			if loop.IsBoundary() {
+				tt.Errorf("expected %v not to be a boundary", loop)
+			}
+
+			// Pushing the loop across a triangle gives a homologous loop
+			pushed := loop.Add(c.ChainGroup(2).NewChainFromSimplices(c.GetSimplex(3, 6, 8)).SignedBoundary())
+			if !loop.IsHomologous(pushed) {
+				tt.Errorf("expected %v to be homologous to %v", loop, pushed)
+			}
+
+			hg := edges.HomologyGroup()
+			a, b := hg.Coordinates(loop), hg.Coordinates(pushed)
+			if len(a) != 2 || len(b) != 2 {
+				tt.Fatalf("expected 2 coordinates, got %v and %v", a, b)
+			}
+			for idx := range a {
+				if a[idx].Cmp(b[idx]) != 0 {
+					tt.Errorf("expected homologous cycles to have the same coordinates, got %v and %v", a, b)
+				}
+			}
+
+			// A single edge is not a cycle
+			edge := edges.NewChainFromSimplices(c.GetSimplex(0, 1))
+			if edge.IsCycle() || edge.IsBoundary() || hg.Coordinates(edge) != nil {
+				tt.Errorf("expected %v not to be a cycle", edge)
+			}
+		})
+	}
+}
+
+func TestChain_BoundedByInExtremeDimensions(t *testing.T) {
+	for _, f := range []Field{Z2, Q} {
+		t.Run(f.String(), func(tt *testing.T) {
+			c := &Complex{}
+			c.SetField(f)
+			c.NewSimplices([]Base{{0, 1, 2}, {3, 4}}...)
+
+			// In the top dimension only the zero Chain is a boundary, and there is no 3-chain to witness it
+			top := c.ChainGroup(2)
+			if witness := top.Zero().BoundedBy(); witness != nil {
+				tt.Errorf("expected no witness in the top dimension, got %v", witness)
+			}
+			if !top.Zero().IsBoundary() {
+				tt.Error("expected the zero 2-chain to be a boundary")
+			}
+
+			// Witnesses below the top dimension are one dimension higher
+			if witness := c.ChainGroup(1).Zero().BoundedBy(); witness == nil || !witness.IsZero() || witness.Dim() != 2 {
+				tt.Errorf("expected the zero 2-chain to witness the zero 1-chain, got %v", witness)
+			}
+			if triangle := top.NewChainFromSimplices(c.GetSimplex(0, 1, 2)); triangle.IsBoundary() {
+				tt.Errorf("expected %v not to be a boundary", triangle)
+			}
+
+			// Every 0-chain is a cycle, but only those with coefficients adding up to zero have a class in the reduced H_0
+			vertices := c.ChainGroup(0)
+			hg := vertices.HomologyGroup()
+
+			vertex := vertices.NewChainFromSimplices(c.GetSimplex(0))
+			if !vertex.IsCycle() {
+				tt.Errorf("expected %v to be a cycle", vertex)
+			}
+			if x := hg.Coordinates(vertex); x != nil {
+				tt.Errorf("expected no coordinates for %v, got %v", vertex, x)
+			}
+
+			across := vertices.NewChainFromCoefficients(map[*Simplex]*big.Rat{
+				c.GetSimplex(0): big.NewRat(1, 1),
+				c.GetSimplex(3): big.NewRat(-1, 1),
+			})
+			if x := hg.Coordinates(across); len(x) != 1 || x[0].Sign() == 0 {
+				tt.Errorf("expected %v to have a non-zero coordinate, got %v", across, x)
+			}
+		})
+	}
+}
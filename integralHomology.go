@@ -0,0 +1,215 @@
+package comptop
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// IntegralHomologyGroup H_p(K; Z) is the homology group of dimension p computed with integer coefficients.
+// Unlike HomologyGroup, which works over Z_2, IntegralHomologyGroup uses oriented boundary matrices and so detects torsion.
+// By the structure theorem for finitely generated abelian groups, H_p(K; Z) = Z^r + Z/t_1 + ... + Z/t_k,
+// where r is the rank of the group and t_1 | t_2 | ... | t_k are its torsion coefficients.
+//
+// More info: https://en.wikipedia.org/wiki/Simplicial_homology#Homology_groups
+type IntegralHomologyGroup struct {
+	chainGroup *ChainGroup
+
+	rank    int
+	torsion []int
+}
+
+// IntegralHomologyGroup returns the homology group of dimension p with integer coefficients.
+func (cg *ChainGroup) IntegralHomologyGroup() *IntegralHomologyGroup {
+	if cg.ihg != nil {
+		return cg.ihg
+	}
+
+	cg.ihg = &IntegralHomologyGroup{
+		chainGroup: cg,
+		torsion:    []int{},
+	}
+
+	// rank Z_p = dim C_p - rank of the boundary map C_p -> C_{p-1}
+	z := cg.Rank()
+	if cg.dim > 0 {
+		if bm := cg.BoundaryMap(); bm != nil {
+			z -= len(bm.integralSmithNormalDiagonal())
+		}
+	}
+
+	// B_p is the image of the boundary map C_{p+1} -> C_p; each diagonal entry greater than 1 contributes torsion
+	var b int
+	if higherGroup := cg.complex.ChainGroup(cg.dim + 1); higherGroup != nil {
+		if bm := higherGroup.BoundaryMap(); bm != nil {
+			diag := bm.integralSmithNormalDiagonal()
+			b = len(diag)
+			for _, t := range diag {
+				if t.Cmp(big.NewInt(1)) > 0 {
+					cg.ihg.torsion = append(cg.ihg.torsion, int(t.Int64()))
+				}
+			}
+		}
+	}
+
+	cg.ihg.rank = z - b
+
+	return cg.ihg
+}
+
+func (ihg *IntegralHomologyGroup) String() string {
+	s := fmt.Sprintf("Z^%d", ihg.rank)
+
+	for _, t := range ihg.torsion {
+		s += fmt.Sprintf(" + Z/%d", t)
+	}
+
+	return s
+}
+
+// ChainGroup returns the ChainGroup whose homology is represented.
+func (ihg *IntegralHomologyGroup) ChainGroup() *ChainGroup {
+	return ihg.chainGroup
+}
+
+// Rank returns the rank of the free part of the group, which is the p^th Betti number of the Complex.
+func (ihg *IntegralHomologyGroup) Rank() int {
+	return ihg.rank
+}
+
+// Torsion returns the torsion coefficients of the group in increasing order, each dividing the next.
+func (ihg *IntegralHomologyGroup) Torsion() []int {
+	torsion := make([]int, len(ihg.torsion))
+	copy(torsion, ihg.torsion)
+
+	return torsion
+}
+
+// IntegralHomologyGroups returns the homology groups with integer coefficients of c in dimensions 0 to p where p is the dimension of c.
+func (c *Complex) IntegralHomologyGroups() []*IntegralHomologyGroup {
+	groups := []*IntegralHomologyGroup{}
+
+	for d := Dim(0); d <= c.dim; d++ {
+		groups = append(groups, c.ChainGroup(d).IntegralHomologyGroup())
+	}
+
+	return groups
+}
+
+// integralSmithNormalDiagonal returns the non-zero diagonal entries of the Smith normal form over Z of the oriented boundary matrix.
+func (bm *BoundaryMap) integralSmithNormalDiagonal() []*big.Int {
+	if bm.zdiag != nil {
+		return bm.zdiag
+	}
+
+	a := [][]*big.Int{}
+	if signed := bm.SignedBoundaryMatrix(); signed != nil {
+		m, n := signed.Dims()
+		a = make([][]*big.Int, m)
+		for row := 0; row < m; row++ {
+			a[row] = make([]*big.Int, n)
+			for col := 0; col < n; col++ {
+				a[row][col] = big.NewInt(int64(signed.At(row, col)))
+			}
+		}
+	}
+
+	bm.zdiag = smithNormalDiagonalZ(a)
+
+	return bm.zdiag
+}
+
+// smithNormalDiagonalZ reduces a in place into its Smith normal form over the integers and returns the non-zero diagonal entries.
+// Each pivot is chosen to be the entry of smallest magnitude, and rows and columns are cleared using integer division;
+// any remainder becomes a smaller pivot and the process repeats until the pivot divides every remaining entry.
+// Entries are arbitrary precision integers since they may grow well past the entries of a during the elimination.
+//
+// More info: https://en.wikipedia.org/wiki/Smith_normal_form#Algorithm
+func smithNormalDiagonalZ(a [][]*big.Int) []*big.Int {
+	diag := []*big.Int{}
+
+	m := len(a)
+	if m == 0 {
+		return diag
+	}
+	n := len(a[0])
+
+	q, r, x := new(big.Int), new(big.Int), new(big.Int)
+
+	for t := 0; t < m && t < n; t++ {
+		for {
+			// Move the smallest non-zero entry of the remaining submatrix into the pivot position
+			pr, pc := -1, -1
+			for row := t; row < m; row++ {
+				for col := t; col < n; col++ {
+					if a[row][col].Sign() == 0 {
+						continue
+					}
+					if pr < 0 || a[row][col].CmpAbs(a[pr][pc]) < 0 {
+						pr, pc = row, col
+					}
+				}
+			}
+
+			if pr < 0 {
+				return diag
+			}
+
+			a[t], a[pr] = a[pr], a[t]
+			for row := 0; row < m; row++ {
+				a[row][t], a[row][pc] = a[row][pc], a[row][t]
+			}
+
+			p := a[t][t]
+			done := true
+
+			// Clear the pivot's column
+			for row := t + 1; row < m; row++ {
+				if q.Quo(a[row][t], p); q.Sign() != 0 {
+					for col := t; col < n; col++ {
+						a[row][col].Sub(a[row][col], x.Mul(q, a[t][col]))
+					}
+				}
+				if a[row][t].Sign() != 0 {
+					done = false
+				}
+			}
+
+			// Clear the pivot's row
+			for col := t + 1; col < n; col++ {
+				if q.Quo(a[t][col], p); q.Sign() != 0 {
+					for row := t; row < m; row++ {
+						a[row][col].Sub(a[row][col], x.Mul(q, a[row][t]))
+					}
+				}
+				if a[t][col].Sign() != 0 {
+					done = false
+				}
+			}
+
+			if !done {
+				continue
+			}
+
+			// The pivot must divide every remaining entry; if it doesn't, bring the offending row up and try again
+			for row := t + 1; row < m && done; row++ {
+				for col := t + 1; col < n; col++ {
+					if r.Rem(a[row][col], p); r.Sign() != 0 {
+						for c := t; c < n; c++ {
+							a[t][c].Add(a[t][c], a[row][c])
+						}
+						done = false
+						break
+					}
+				}
+			}
+
+			if done {
+				break
+			}
+		}
+
+		diag = append(diag, new(big.Int).Abs(a[t][t]))
+	}
+
+	return diag
+}
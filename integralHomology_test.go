@@ -0,0 +1,111 @@
+package comptop
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestChainGroup_IntegralHomologyGroup(t *testing.T) {
+	type testcase struct {
+		name    string
+		bases   []Base
+		ranks   []int
+		torsion [][]int
+	}
+
+	tests := []testcase{
+		{
+			name: "torus",
+			bases: []Base{
+				{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5},
+				{3, 4, 6}, {4, 6, 7}, {4, 5, 7}, {5, 7, 8}, {3, 5, 8}, {3, 6, 8},
+				{0, 6, 7}, {0, 1, 7}, {1, 7, 8}, {1, 2, 8}, {2, 6, 8}, {0, 2, 6},
+			},
+			ranks:   []int{1, 2, 1},
+			torsion: [][]int{{}, {}, {}},
+		},
+		{
+			name: "projective plane",
+			bases: []Base{
+				{0, 1, 2}, {0, 2, 3}, {0, 3, 4}, {0, 4, 5}, {0, 1, 5},
+				{1, 2, 4}, {2, 3, 5}, {1, 3, 4}, {2, 4, 5}, {1, 3, 5},
+			},
+			ranks:   []int{1, 0, 0},
+			torsion: [][]int{{}, {2}, {}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			c := &Complex{}
+			c.NewSimplices(test.bases...)
+
+			groups := c.IntegralHomologyGroups()
+			if len(groups) != len(test.ranks) {
+				tt.Fatalf("expected %d homology groups, got %d", len(test.ranks), len(groups))
+			}
+
+			for d, hg := range groups {
+				if hg.Rank() != test.ranks[d] {
+					tt.Errorf("expected H_%d to have rank %d, got %v", d, test.ranks[d], hg)
+				}
+
+				torsion := hg.Torsion()
+				if len(torsion) != len(test.torsion[d]) {
+					tt.Fatalf("expected H_%d to have torsion %v, got %v", d, test.torsion[d], hg)
+				}
+				for idx := range torsion {
+					if torsion[idx] != test.torsion[d][idx] {
+						tt.Errorf("expected H_%d to have torsion %v, got %v", d, test.torsion[d], hg)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSmithNormalDiagonalZ(t *testing.T) {
+	matrix := func(rows ...[]int64) [][]*big.Int {
+		a := [][]*big.Int{}
+		for _, row := range rows {
+			r := []*big.Int{}
+			for _, x := range row {
+				r = append(r, big.NewInt(x))
+			}
+			a = append(a, r)
+		}
+		return a
+	}
+
+	m := int64(1) << 40
+	overflow := new(big.Int).Lsh(big.NewInt(1), 80)
+	overflow.Sub(overflow, big.NewInt(1))
+
+	for name, tc := range map[string]struct {
+		a        [][]*big.Int
+		expected []*big.Int
+	}{
+		"small": {
+			a:        matrix([]int64{2, 4, 4}, []int64{-6, 6, 12}, []int64{10, -4, -16}),
+			expected: []*big.Int{big.NewInt(2), big.NewInt(6), big.NewInt(12)},
+		},
+		// Clearing the first column leaves 1 - 2^80, which doesn't fit in an int64
+		"past int64": {
+			a:        matrix([]int64{1, m}, []int64{m, 1}),
+			expected: []*big.Int{big.NewInt(1), overflow},
+		},
+	} {
+		t.Run(name, func(tt *testing.T) {
+			diag := smithNormalDiagonalZ(tc.a)
+			if len(diag) != len(tc.expected) {
+				tt.Fatalf("expected diagonal %v, got %v", tc.expected, diag)
+			}
+
+			for idx := range diag {
+				if diag[idx].Cmp(tc.expected[idx]) != 0 {
+					tt.Fatalf("expected diagonal %v, got %v", tc.expected, diag)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package comptop
+
+// IsPure returns true if every principle Simplex of c has the same dimension as c.
+func (c *Complex) IsPure() bool {
+	for smplx := range c.PrincipleSimplices().set {
+		if smplx.Dim() != c.dim {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsPseudomanifold returns true if c is a pseudomanifold, possibly with boundary:
+// c is pure, every (p-1)-simplex is a face of one or two p-simplices (where p is the dimension of c),
+// and any two p-simplices can be joined by a sequence of p-simplices, each sharing a (p-1)-face with the next.
+//
+// More info: https://en.wikipedia.org/wiki/Pseudomanifold
+func (c *Complex) IsPseudomanifold() bool {
+	if !c.IsPure() {
+		return false
+	}
+
+	if c.dim == 0 {
+		return len(c.GetdSimplices(0)) == 1
+	}
+
+	for _, face := range c.GetdSimplices(c.dim - 1) {
+		if n := face.Cofaces(c.dim).Card(); n == 0 || n > 2 {
+			return false
+		}
+	}
+
+	return c.isStronglyConnected()
+}
+
+// isStronglyConnected returns true if the top dimensional simplices of c are connected through their codimension 1 faces.
+func (c *Complex) isStronglyConnected() bool {
+	top := c.GetdSimplices(c.dim)
+	if len(top) == 0 {
+		return true
+	}
+
+	seen := map[*Simplex]struct{}{top[0]: {}}
+	stack := []*Simplex{top[0]}
+	for len(stack) > 0 {
+		n := len(stack) - 1
+		smplx := stack[n]
+		stack = stack[:n]
+
+		for _, face := range smplx.Faces(c.dim - 1).Slice() {
+			for _, nbr := range face.Cofaces(c.dim).Slice() {
+				if _, exists := seen[nbr]; exists {
+					continue
+				}
+				seen[nbr] = struct{}{}
+				stack = append(stack, nbr)
+			}
+		}
+	}
+
+	return len(seen) == len(top)
+}
+
+// NonManifoldSimplices returns the simplices of c around which c fails to look like a manifold:
+// principle simplices of dimension lower than c, (p-1)-simplices which are faces of more than two p-simplices (where p is the dimension of c),
+// and vertices whose link does not have the homology (over Z_2) of a (p-1)-sphere or a point.
+func (c *Complex) NonManifoldSimplices() *SimplicialSet {
+	bad := NewSimplicialSet()
+
+	for smplx := range c.PrincipleSimplices().set {
+		if smplx.Dim() != c.dim {
+			bad.Add(smplx)
+		}
+	}
+
+	if c.dim == 0 {
+		return bad
+	}
+
+	for _, face := range c.GetdSimplices(c.dim - 1) {
+		if face.Cofaces(c.dim).Card() > 2 {
+			bad.Add(face)
+		}
+	}
+
+	for _, vertex := range c.GetdSimplices(0) {
+		if _, impure := bad.set[vertex]; impure {
+			continue
+		}
+
+		if _, ok := c.vertexLinkType(vertex); !ok {
+			bad.Add(vertex)
+		}
+	}
+
+	return bad
+}
+
+// vertexLinkType reports whether the link of v has the homology over Z_2 of a sphere of dimension one less than c (interior vertex)
+// or of a point (boundary vertex). The second return value is false if it has neither.
+func (c *Complex) vertexLinkType(v *Simplex) (boundary bool, ok bool) {
+	link := v.Link()
+	if link.ChainGroup(0) == nil || link.ChainGroup(0).Rank() == 0 || link.dim != c.dim-1 {
+		return false, false
+	}
+
+	// Every face of the link must be a face of at most two top dimensional simplices of the link
+	if link.dim > 0 {
+		for _, face := range link.GetdSimplices(link.dim - 1) {
+			if face.Cofaces(link.dim).Card() > 2 {
+				return false, false
+			}
+		}
+	}
+
+	rb := link.ReducedBettiNumbers()
+
+	sphere, ball := true, true
+	for d, b := range rb {
+		if b != 0 {
+			ball = false
+		}
+		if (Dim(d) == link.dim && b != 1) || (Dim(d) != link.dim && b != 0) {
+			sphere = false
+		}
+	}
+
+	switch {
+	case sphere:
+		return false, true
+	case ball:
+		return true, true
+	}
+
+	return false, false
+}
+
+// IsManifold returns true if c is a (homology) manifold, possibly with boundary:
+// c is a pseudomanifold and the link of every vertex has the homology over Z_2 of a sphere or of a point.
+// For complexes of dimension at most 2 this coincides with c being a topological manifold.
+//
+// More info: https://en.wikipedia.org/wiki/Homology_manifold
+func (c *Complex) IsManifold() bool {
+	if !c.IsPseudomanifold() {
+		return false
+	}
+
+	return c.NonManifoldSimplices().Card() == 0
+}
+
+// IsClosedManifold returns true if c is a manifold without boundary.
+func (c *Complex) IsClosedManifold() bool {
+	if !c.IsManifold() {
+		return false
+	}
+
+	if c.dim == 0 {
+		return true
+	}
+
+	for _, face := range c.GetdSimplices(c.dim - 1) {
+		if face.Cofaces(c.dim).Card() != 2 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// BoundaryComplex returns the sub-Complex made up of the (p-1)-simplices which are faces of exactly one p-simplex, along with their faces
+// (where p is the dimension of c). For a manifold with boundary, this is its boundary; for a closed manifold it is empty.
+// Each Simplex in the returned Complex carries the Data of the matching Simplex in c.
+func (c *Complex) BoundaryComplex() *Complex {
+	bases := []Base{}
+
+	if c.dim > 0 {
+		for _, face := range c.GetdSimplices(c.dim - 1) {
+			if face.Cofaces(c.dim).Card() == 1 {
+				bases = append(bases, face.Base())
+			}
+		}
+	}
+
+	return c.subcomplex(bases)
+}
+
+// IsOrientable returns true if c is a pseudomanifold with a consistent orientation.
+func (c *Complex) IsOrientable() bool {
+	_, ok := c.Orientation()
+	return ok
+}
+
+// Orientation returns a consistent orientation of the top dimensional simplices of the pseudomanifold c, if one exists.
+// The orientation of each Simplex is given as +1 or -1, relative to the order of its sorted base.
+// Orientations are consistent when every (p-1)-simplex shared by two p-simplices is induced with opposite signs by them.
+// The second return value is false if c is not a pseudomanifold or is not orientable.
+//
+// More info: https://en.wikipedia.org/wiki/Orientability
+func (c *Complex) Orientation() (map[*Simplex]int, bool) {
+	if !c.IsPseudomanifold() {
+		return nil, false
+	}
+
+	orientation := map[*Simplex]int{}
+
+	for _, start := range c.GetdSimplices(c.dim) {
+		if _, done := orientation[start]; done {
+			continue
+		}
+
+		orientation[start] = 1
+		stack := []*Simplex{start}
+		for len(stack) > 0 {
+			n := len(stack) - 1
+			smplx := stack[n]
+			stack = stack[:n]
+
+			if c.dim == 0 {
+				continue
+			}
+
+			for _, face := range smplx.Faces(c.dim - 1).Slice() {
+				// The sign with which smplx induces the orientation of face
+				induced := orientation[smplx] * smplx.faceSign(&face.simplex)
+
+				for _, nbr := range face.Cofaces(c.dim).Slice() {
+					if nbr == smplx {
+						continue
+					}
+
+					// nbr must induce the opposite orientation on face
+					want := -induced * nbr.faceSign(&face.simplex)
+					if o, done := orientation[nbr]; done {
+						if o != want {
+							return nil, false
+						}
+						continue
+					}
+
+					orientation[nbr] = want
+					stack = append(stack, nbr)
+				}
+			}
+		}
+	}
+
+	return orientation, true
+}
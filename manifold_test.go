@@ -0,0 +1,86 @@
+package comptop
+
+import "testing"
+
+func TestComplex_IsManifold(t *testing.T) {
+	torus := &Complex{}
+	torus.NewSimplices([]Base{
+		{0, 1, 4}, {1, 4, 5}, {1, 2, 5}, {2, 5, 6}, {0, 2, 6}, {0, 4, 6},
+		{4, 5, 7}, {5, 7, 8}, {5, 6, 8}, {6, 8, 9}, {4, 6, 9}, {4, 7, 9},
+		{0, 7, 8}, {0, 1, 8}, {1, 8, 9}, {1, 2, 9}, {2, 7, 9}, {0, 2, 7},
+	}...)
+
+	if !torus.IsClosedManifold() {
+		t.Error("expected the torus to be a closed manifold")
+	}
+	if !torus.IsOrientable() {
+		t.Error("expected the torus to be orientable")
+	}
+	if count := torus.BoundaryComplex().ChainGroup(0).Rank(); count != 0 {
+		t.Errorf("expected the torus to have an empty boundary, got %d vertices", count)
+	}
+
+	// The orientation must induce opposite signs on every shared edge
+	orientation, _ := torus.Orientation()
+	for _, edge := range torus.GetdSimplices(1) {
+		sum := 0
+		for _, tri := range edge.Cofaces(2).Slice() {
+			sum += orientation[tri] * tri.faceSign(&edge.simplex)
+		}
+		if sum != 0 {
+			t.Fatalf("orientation is inconsistent along %v", edge)
+		}
+	}
+
+	mobius := &Complex{}
+	mobius.NewSimplices([]Base{
+		{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {2, 3, 5}, {0, 3, 5},
+	}...)
+
+	if !mobius.IsManifold() || mobius.IsClosedManifold() {
+		t.Error("expected the Möbius strip to be a manifold with boundary")
+	}
+	if mobius.IsOrientable() {
+		t.Error("expected the Möbius strip to be non-orientable")
+	}
+
+	// The boundary of the Möbius strip is a single circle
+	expectedBN := []int{1, 1}
+	bn := mobius.BoundaryComplex().BettiNumbers()
+	if len(expectedBN) != len(bn) {
+		t.Fatalf("invalid number of Betti numbers: %v", bn)
+	}
+	for idx, ebn := range expectedBN {
+		if bn[idx] != ebn {
+			t.Fatalf("Betti number %d is wrong; expected %d received %d", idx, ebn, bn[idx])
+		}
+	}
+
+	// Two triangles meeting at a single vertex are pinched at that vertex
+	bowtie := &Complex{}
+	bowtie.NewSimplices([]Base{{0, 1, 2}, {0, 3, 4}}...)
+
+	if bowtie.IsManifold() {
+		t.Error("expected the bow-tie not to be a manifold")
+	}
+	bad := bowtie.NonManifoldSimplices()
+	if bad.Card() != 1 || !inSet(bad, bowtie.GetSimplex(0)) {
+		t.Errorf("expected the pinch vertex to be the only non-manifold simplex, got %v", bad)
+	}
+
+	// Three triangles sharing an edge
+	book := &Complex{}
+	book.NewSimplices([]Base{{0, 1, 2}, {0, 1, 3}, {0, 1, 4}}...)
+
+	if book.IsPseudomanifold() {
+		t.Error("expected three triangles sharing an edge not to be a pseudomanifold")
+	}
+	if !inSet(book.NonManifoldSimplices(), book.GetSimplex(0, 1)) {
+		t.Error("expected the shared edge to be non-manifold")
+	}
+}
+
+func inSet(ss *SimplicialSet, smplx *Simplex) bool {
+	_, exists := ss.set[smplx]
+	return exists
+}
@@ -0,0 +1,189 @@
+package comptop
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// SimplexWeight returns the weight of a Simplex; weights must not be negative.
+type SimplexWeight func(*Simplex) float64
+
+// exactBoundaryRank is the largest rank of the boundary group for which MinimalHomologous searches every homologous cycle.
+const exactBoundaryRank = 16
+
+// MinimalHomologous returns a cycle homologous to c with the smallest weight that could be found, where the weight of a Chain is the sum of the weights of its simplices
+// (over Q, each weight is multiplied by the absolute value of the coefficient of the Simplex).
+// If w is nil, the weight of a Simplex is its Data when that is a float64, and 1 otherwise.
+// MinimalHomologous returns nil if c is not a cycle.
+//
+// Over Z_2, when the boundary group has rank at most 16, every cycle homologous to c is visited (in Gray code order) and the result is exact.
+// Otherwise the result comes from a local search and is only guaranteed not to be improved by adding the boundary of any single (p+1)-simplex:
+// starting from c, the boundary of a (p+1)-simplex (scaled to cancel one of the coefficients of the cycle when the field is not Z_2) is added whenever doing so lowers the weight.
+//
+// More info: 'Quantifying Homology Classes' by Chen & Freedman.
+func (c *Chain) MinimalHomologous(w SimplexWeight) *Chain {
+	if !c.IsCycle() {
+		return nil
+	}
+
+	if w == nil {
+		w = dataWeight
+	}
+
+	cg := c.chaingroup
+	higherGroup := cg.complex.ChainGroup(cg.dim + 1)
+	if higherGroup == nil || higherGroup.Rank() == 0 {
+		// There are no boundaries, so c is alone in its class
+		return c
+	}
+
+	if !isBinary(cg.Field()) {
+		return cg.localMinimalHomologous(c, w)
+	}
+
+	cg.sortIdxs()
+	rows := make(map[*Simplex]int, len(cg.idxs))
+	weights := make([]float64, len(cg.idxs))
+	for row, idx := range cg.idxs {
+		rows[cg.Simplex(idx)] = row
+		weights[row] = w(cg.Simplex(idx))
+	}
+
+	words := (len(cg.idxs) + 63) / 64
+	toVector := func(chain *Chain) []uint64 {
+		v := make([]uint64, words)
+		for _, smplx := range chain.simplices {
+			v[rows[smplx]/64] ^= 1 << uint(rows[smplx]%64)
+		}
+		return v
+	}
+
+	weight := func(v []uint64) float64 {
+		var total float64
+		for i, word := range v {
+			for word != 0 {
+				total += weights[64*i+bits.TrailingZeros64(word)]
+				word &= word - 1
+			}
+		}
+		return total
+	}
+
+	xor := func(dst, src []uint64) {
+		for i := range dst {
+			dst[i] ^= src[i]
+		}
+	}
+
+	cur := toVector(c)
+	best := append([]uint64(nil), cur...)
+	bestWeight := weight(cur)
+
+	boundaries := [][]uint64{}
+	for _, chain := range cg.BoundaryGroup().Basis() {
+		boundaries = append(boundaries, toVector(chain))
+	}
+
+	if len(boundaries) <= exactBoundaryRank {
+		// Visit c + every boundary, flipping a single basis boundary at each step
+		for i := uint(1); i < 1<<uint(len(boundaries)); i++ {
+			xor(cur, boundaries[bits.TrailingZeros(i)])
+			if cw := weight(cur); cw < bestWeight {
+				bestWeight = cw
+				copy(best, cur)
+			}
+		}
+	} else {
+		// Local search over the boundaries of single (p+1)-simplices
+		moves := [][]uint64{}
+		for _, smplx := range higherGroup.Simplices() {
+			moves = append(moves, toVector(cg.NewChainFromSimplices(smplx.Faces(cg.dim).Slice()...)))
+		}
+
+		for improved := true; improved; {
+			improved = false
+			for _, move := range moves {
+				xor(best, move)
+				if bw := weight(best); bw < bestWeight {
+					bestWeight = bw
+					improved = true
+					continue
+				}
+				xor(best, move)
+			}
+		}
+	}
+
+	simplices := []*Simplex{}
+	for row := range cg.idxs {
+		if best[row/64]&(1<<uint(row%64)) != 0 {
+			simplices = append(simplices, cg.Simplex(cg.idxs[row]))
+		}
+	}
+
+	return cg.NewChainFromSimplices(simplices...)
+}
+
+// localMinimalHomologous improves c by adding multiples of the boundaries of single (p+1)-simplices until no such move lowers its weight.
+func (cg *ChainGroup) localMinimalHomologous(c *Chain, w SimplexWeight) *Chain {
+	f := cg.Field()
+	higherGroup := cg.complex.ChainGroup(cg.dim + 1)
+
+	weight := func(coeffs map[*Simplex]*big.Rat) float64 {
+		var total float64
+		for smplx, x := range coeffs {
+			if x.Sign() == 0 {
+				continue
+			}
+			a := 1.0
+			if f.Characteristic() == 0 {
+				a, _ = new(big.Rat).Abs(x).Float64()
+			}
+			total += a * w(smplx)
+		}
+		return total
+	}
+
+	best := map[*Simplex]*big.Rat{}
+	for _, smplx := range c.simplices {
+		best[smplx] = c.Coefficient(smplx)
+	}
+	bestWeight := weight(best)
+
+	for improved := true; improved; {
+		improved = false
+		for _, smplx := range higherGroup.Simplices() {
+			faces := smplx.Faces(cg.dim).Slice()
+
+			// Try each multiple of the boundary of smplx which cancels one of its faces out of the cycle
+			for _, face := range faces {
+				x, inCycle := best[face]
+				if !inCycle || x.Sign() == 0 {
+					continue
+				}
+
+				lambda := fieldMul(f, x, big.NewRat(-int64(smplx.faceSign(&face.simplex)), 1))
+
+				next := make(map[*Simplex]*big.Rat, len(best)+len(faces))
+				for s, y := range best {
+					next[s] = y
+				}
+				for _, g := range faces {
+					y := fieldMul(f, lambda, big.NewRat(int64(smplx.faceSign(&g.simplex)), 1))
+					if z, exists := next[g]; exists {
+						y = fieldAdd(f, y, z)
+					}
+					next[g] = y
+				}
+
+				if nw := weight(next); nw < bestWeight {
+					best, bestWeight = next, nw
+					improved = true
+					break
+				}
+			}
+		}
+	}
+
+	return cg.NewChainFromCoefficients(best)
+}
@@ -0,0 +1,78 @@
+package comptop
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestChain_MinimalHomologous(t *testing.T) {
+	for _, f := range []Field{Z2, Q} {
+		t.Run("annulus/"+f.String(), func(tt *testing.T) {
+			// Inner triangle [0 1 2] and outer triangle [3 4 5], with heavy outer edges
+			c := &Complex{}
+			c.SetField(f)
+			c.NewSimplices([]Base{{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5}}...)
+
+			outer := map[*Simplex]bool{c.GetSimplex(3, 4): true, c.GetSimplex(4, 5): true, c.GetSimplex(3, 5): true}
+			w := func(s *Simplex) float64 {
+				if outer[s] {
+					return 5
+				}
+				return 1
+			}
+
+			loop := c.ChainGroup(1).NewChainFromCoefficients(map[*Simplex]*big.Rat{
+				c.GetSimplex(3, 4): big.NewRat(1, 1),
+				c.GetSimplex(4, 5): big.NewRat(1, 1),
+				c.GetSimplex(3, 5): big.NewRat(-1, 1),
+			})
+
+			min := loop.MinimalHomologous(w)
+			if min == nil {
+				tt.Fatal("expected a cycle")
+			}
+			if !min.IsHomologous(loop) {
+				tt.Errorf("expected %v to be homologous to %v", min, loop)
+			}
+
+			for _, smplx := range min.Simplices() {
+				if outer[smplx] {
+					tt.Errorf("expected the inner triangle, got %v", min)
+				}
+			}
+			if min.Len() != 3 {
+				tt.Errorf("expected 3 edges, got %v", min)
+			}
+
+			// Edges are not cycles
+			if c.ChainGroup(1).NewChainFromSimplices(c.GetSimplex(0, 1)).MinimalHomologous(w) != nil {
+				tt.Error("expected nil for a chain which is not a cycle")
+			}
+		})
+	}
+
+	t.Run("torus", func(tt *testing.T) {
+		// The boundary group has rank 17, so the local search is used
+		c := &Complex{}
+		c.NewSimplices([]Base{
+			{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5},
+			{3, 4, 6}, {4, 6, 7}, {4, 5, 7}, {5, 7, 8}, {3, 5, 8}, {3, 6, 8},
+			{0, 6, 7}, {0, 1, 7}, {1, 7, 8}, {1, 2, 8}, {2, 6, 8}, {0, 2, 6},
+		}...)
+
+		edges := c.ChainGroup(1)
+		loop := edges.NewChainFromSimplices(c.GetSimplex(0, 3), c.GetSimplex(3, 6), c.GetSimplex(0, 6))
+		pushed := loop.Add(c.ChainGroup(2).NewChainFromSimplices(c.GetSimplex(3, 6, 8)).Boundary())
+		if pushed.Len() != 4 {
+			tt.Fatalf("expected a loop of 4 edges, got %v", pushed)
+		}
+
+		min := pushed.MinimalHomologous(nil)
+		if !min.IsHomologous(loop) {
+			tt.Errorf("expected %v to be homologous to %v", min, loop)
+		}
+		if min.Len() != 3 {
+			tt.Errorf("expected a loop of 3 edges, got %v", min)
+		}
+	})
+}
@@ -3,6 +3,10 @@ package comptop
 // NewSimplex adds a Simplex to c.
 // All lower dimensional faces of the new Simplex are computed and automatically added to c.
 func (c *Complex) NewSimplex(base ...Index) *Simplex {
+	if c.tree != nil {
+		return c.insertIntoTree(nil, base)
+	}
+
 	if c.chainGroups == nil {
 		c.chainGroups = ChainGroups{}
 	}
@@ -64,7 +68,8 @@ func (c *Complex) NewSimplex(base ...Index) *Simplex {
 		}
 	}
 
-	// cached results should be reset
+	// link the new simplices to their faces and reset cached results
+	c.linkPending()
 	c.resetCache()
 
 	return newSimplex
@@ -73,6 +78,15 @@ func (c *Complex) NewSimplex(base ...Index) *Simplex {
 // NewSimplex adds multiple simplices to c.
 // All lower dimensional faces of each new Simplex are computed and automatically added to c.
 func (c *Complex) NewSimplices(bases ...Base) *SimplicialSet {
+	if c.tree != nil {
+		set := NewSimplicialSet()
+		for _, base := range bases {
+			set.Add(c.insertIntoTree(nil, base))
+		}
+
+		return set
+	}
+
 	if c.chainGroups == nil {
 		c.chainGroups = ChainGroups{}
 	}
@@ -145,7 +159,8 @@ func (c *Complex) NewSimplices(bases ...Base) *SimplicialSet {
 		}
 	}
 
-	// cached results should be reset
+	// link the new simplices to their faces and reset cached results
+	c.linkPending()
 	c.resetCache()
 
 	return &SimplicialSet{set: set}
@@ -157,6 +172,10 @@ type DataProvider func(Dim, Index, Base) interface{}
 // NewSimplex adds a Simplex to c while using dp to attach data to each newly created Simplex.
 // All lower dimensional faces of the new Simplex are computed and automatically added to c.
 func (c *Complex) NewSimplexWithData(dp DataProvider, base ...Index) *Simplex {
+	if c.tree != nil {
+		return c.insertIntoTree(dp, base)
+	}
+
 	if c.chainGroups == nil {
 		c.chainGroups = ChainGroups{}
 	}
@@ -221,7 +240,8 @@ func (c *Complex) NewSimplexWithData(dp DataProvider, base ...Index) *Simplex {
 		}
 	}
 
-	// cached results should be reset
+	// link the new simplices to their faces and reset cached results
+	c.linkPending()
 	c.resetCache()
 
 	return newSimplex
@@ -230,6 +250,15 @@ func (c *Complex) NewSimplexWithData(dp DataProvider, base ...Index) *Simplex {
 // NewSimplex adds multiple simplices to c, using dp to attach data to each newly created Simplex.
 // All lower dimensional faces of each new Simplex are computed and automatically added to c.
 func (c *Complex) NewSimplicesWithData(dp DataProvider, bases ...Base) *SimplicialSet {
+	if c.tree != nil {
+		set := NewSimplicialSet()
+		for _, base := range bases {
+			set.Add(c.insertIntoTree(dp, base))
+		}
+
+		return set
+	}
+
 	if c.chainGroups == nil {
 		c.chainGroups = ChainGroups{}
 	}
@@ -297,7 +326,8 @@ func (c *Complex) NewSimplicesWithData(dp DataProvider, bases ...Base) *Simplici
 		set[newSimplex] = struct{}{}
 	}
 
-	// cached results should be reset
+	// link the new simplices to their faces and reset cached results
+	c.linkPending()
 	c.resetCache()
 
 	return &SimplicialSet{set: set}
@@ -0,0 +1,167 @@
+package comptop
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// OrientedSimplex is a Simplex together with an ordering of its vertices and a sign.
+// Two orderings give the same orientation when they differ by an even permutation;
+// an odd permutation, or flipping the sign, reverses it.
+// The orientation of a Simplex given by the increasing order of its vertices is considered positive.
+//
+// More info: https://en.wikipedia.org/wiki/Simplicial_homology#Orientations
+type OrientedSimplex struct {
+	simplex  *Simplex
+	vertices Base
+	sign     int
+}
+
+// Oriented returns s oriented by the given ordering of its vertices; with no vertices, s is oriented by the increasing order of its vertices.
+// Oriented panics if vertices is not a permutation of the base of s.
+func (s *Simplex) Oriented(vertices ...Index) *OrientedSimplex {
+	s.sort()
+
+	if len(vertices) == 0 {
+		vertices = s.base
+	}
+
+	if len(vertices) != len(s.base) || !s.simplex.equals(&simplex{base: normalizeBase(vertices)}) {
+		panic(fmt.Sprintf("%v is not an ordering of the vertices of %v", vertices, s))
+	}
+
+	return &OrientedSimplex{
+		simplex:  s,
+		vertices: append(Base(nil), vertices...),
+		sign:     1,
+	}
+}
+
+func (os *OrientedSimplex) String() string {
+	if os.sign < 0 {
+		return fmt.Sprintf("-%v", os.vertices)
+	}
+
+	return fmt.Sprintf("%v", os.vertices)
+}
+
+// Simplex returns the underlying, unoriented Simplex.
+func (os *OrientedSimplex) Simplex() *Simplex {
+	return os.simplex
+}
+
+// Vertices returns a copy of the ordered vertices of os.
+func (os *OrientedSimplex) Vertices() Base {
+	return append(Base(nil), os.vertices...)
+}
+
+// Dim returns the dimension of os.
+func (os *OrientedSimplex) Dim() Dim {
+	return Dim(len(os.vertices)) - 1
+}
+
+// Parity returns the sign of the permutation taking the increasing order of the vertices of os to their order in os.
+func (os *OrientedSimplex) Parity() int {
+	return permutationParity(os.vertices)
+}
+
+// Orientation returns +1 if os has the same orientation as its Simplex with vertices in increasing order, and -1 otherwise.
+func (os *OrientedSimplex) Orientation() int {
+	return os.sign * os.Parity()
+}
+
+// Neg returns os with the opposite orientation.
+func (os *OrientedSimplex) Neg() *OrientedSimplex {
+	return &OrientedSimplex{
+		simplex:  os.simplex,
+		vertices: os.Vertices(),
+		sign:     -os.sign,
+	}
+}
+
+// Equals returns true if os and f are the same Simplex with the same orientation.
+func (os *OrientedSimplex) Equals(f *OrientedSimplex) bool {
+	return os.simplex == f.simplex && os.Orientation() == f.Orientation()
+}
+
+// Boundary returns the codimension 1 faces of os with their induced orientations:
+// the j^th face omits the j^th vertex of os and carries the sign (-1)^j.
+//
+// More info: https://en.wikipedia.org/wiki/Simplicial_homology#Boundaries_and_cycles
+func (os *OrientedSimplex) Boundary() []*OrientedSimplex {
+	faces := []*OrientedSimplex{}
+	if os.Dim() == 0 {
+		return faces
+	}
+
+	complex := os.simplex.complex
+	n := len(os.vertices)
+	for j := 0; j < n; j++ {
+		vertices := make(Base, 0, n-1)
+		vertices = append(vertices, os.vertices[:j]...)
+		vertices = append(vertices, os.vertices[j+1:]...)
+
+		sign := os.sign
+		if j%2 == 1 {
+			sign = -sign
+		}
+
+		faces = append(faces, &OrientedSimplex{
+			simplex:  complex.GetSimplex(vertices...),
+			vertices: vertices,
+			sign:     sign,
+		})
+	}
+
+	return faces
+}
+
+// permutationParity returns +1 if sorting b takes an even number of transpositions, and -1 otherwise.
+func permutationParity(b Base) int {
+	parity := 1
+	for i := range b {
+		for j := i + 1; j < len(b); j++ {
+			if b[i] > b[j] {
+				parity = -parity
+			}
+		}
+	}
+
+	return parity
+}
+
+// SignedBoundary returns the oriented boundary of c as a Chain over the Field of c, one dimension lower.
+// Every Simplex is oriented by the increasing order of its vertices, so that the j^th face of a Simplex carries the sign (-1)^j;
+// applying SignedBoundary twice always gives zero, whatever the Field.
+// Over Z_2 the signs vanish and SignedBoundary agrees with Boundary.
+// SignedBoundary returns nil if c is 0-dimensional.
+func (c *Chain) SignedBoundary() *Chain {
+	if c.dim == 0 {
+		return nil
+	}
+
+	boundary := c.complex.ChainGroup(c.dim - 1).NewChainFromCoefficients(c.signedFaces())
+	if boundary != boundary.chaingroup.zero {
+		boundary.isCycle = true
+	}
+
+	return boundary
+}
+
+// signedFaces sums the oriented faces of each simplex of c, weighted by its coefficient.
+// The sums are not reduced over the Field of c.
+func (c *Chain) signedFaces() map[*Simplex]*big.Rat {
+	coeffs := map[*Simplex]*big.Rat{}
+	for _, smplx := range c.simplices {
+		x := c.Coefficient(smplx)
+		for _, face := range smplx.Faces(c.dim - 1).Slice() {
+			y := new(big.Rat).Mul(x, big.NewRat(int64(smplx.faceSign(&face.simplex)), 1))
+			if z, exists := coeffs[face]; exists {
+				y.Add(y, z)
+			}
+			coeffs[face] = y
+		}
+	}
+
+	return coeffs
+}
@@ -0,0 +1,90 @@
+package comptop
+
+import (
+	"math/big"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestOrientedSimplex(t *testing.T) {
+	c := &Complex{}
+	c.NewSimplices([]Base{{0, 1, 2, 3}}...)
+	tet := c.GetSimplex(0, 1, 2, 3)
+
+	if o := tet.Oriented().Orientation(); o != 1 {
+		t.Errorf("expected sorted vertices to be positively oriented, got %d", o)
+	}
+	if o := tet.Oriented(1, 0, 2, 3).Orientation(); o != -1 {
+		t.Errorf("expected a transposition to reverse the orientation, got %d", o)
+	}
+	if !tet.Oriented(1, 2, 0, 3).Equals(tet.Oriented()) {
+		t.Error("expected a 3-cycle to preserve the orientation")
+	}
+	if !tet.Oriented(1, 0, 2, 3).Neg().Equals(tet.Oriented()) {
+		t.Error("expected Neg to reverse the orientation")
+	}
+
+	// The boundary of the boundary cancels out for any ordering of the vertices
+	for _, o := range []*OrientedSimplex{tet.Oriented(), tet.Oriented(3, 1, 0, 2)} {
+		sum := map[*Simplex]int{}
+		for _, face := range o.Boundary() {
+			for _, edge := range face.Boundary() {
+				sum[edge.Simplex()] += edge.Orientation()
+			}
+		}
+		for edge, x := range sum {
+			if x != 0 {
+				t.Fatalf("boundary of the boundary of %v has coefficient %d on %v", o, x, edge)
+			}
+		}
+	}
+}
+
+func TestChain_SignedBoundary(t *testing.T) {
+	c := &Complex{}
+	c.NewSimplices([]Base{
+		{0, 1, 4}, {1, 4, 5}, {1, 2, 5}, {2, 5, 6}, {0, 2, 6}, {0, 4, 6},
+		{4, 5, 7}, {5, 7, 8}, {5, 6, 8}, {6, 8, 9}, {4, 6, 9}, {4, 7, 9},
+		{0, 7, 8}, {0, 1, 8}, {1, 8, 9}, {1, 2, 9}, {2, 7, 9}, {0, 2, 7},
+	}...)
+
+	// boundary o boundary = 0 for the signed boundary matrices
+	var dd mat.Dense
+	dd.Mul(c.ChainGroup(1).SignedBoundaryMatrix(), c.ChainGroup(2).SignedBoundaryMatrix())
+	m, n := dd.Dims()
+	for row := 0; row < m; row++ {
+		for col := 0; col < n; col++ {
+			if dd.At(row, col) != 0 {
+				t.Fatalf("boundary of the boundary is non-zero at (%d, %d)", row, col)
+			}
+		}
+	}
+
+	for _, f := range []Field{Z2, Zp(3), Q} {
+		t.Run(f.String(), func(tt *testing.T) {
+			c.SetField(f)
+
+			edge := c.GetSimplex(1, 4)
+			boundary := c.ChainGroup(1).NewChainFromSimplices(edge).SignedBoundary()
+			if boundary.Dim() != 0 || boundary.Field() != f {
+				tt.Fatalf("expected a 0-chain over %v, got %v", f, boundary)
+			}
+			if x := boundary.Coefficient(c.GetSimplex(1)); x.Cmp(f.Reduce(big.NewRat(-1, 1))) != 0 {
+				tt.Errorf("expected the boundary of [1 4] to be [4] - [1], got %v on [1]", x)
+			}
+			if x := boundary.Coefficient(c.GetSimplex(4)); x.Cmp(big.NewRat(1, 1)) != 0 {
+				tt.Errorf("expected the boundary of [1 4] to be [4] - [1], got %v on [4]", x)
+			}
+
+			tri := c.ChainGroup(2).NewChainFromSimplices(c.GetdSimplices(2)...)
+			if bb := tri.SignedBoundary().SignedBoundary(); !bb.IsZero() {
+				tt.Errorf("expected the boundary of a boundary to vanish, got %v", bb)
+			}
+
+			if vertex := c.ChainGroup(0).NewChainFromSimplices(c.GetSimplex(0)); vertex.SignedBoundary() != nil {
+				tt.Errorf("expected no boundary for a 0-chain")
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package comptop
+
+import (
+	"fmt"
+	"math"
+)
+
+// PersistenceInterval records the lifetime of a homology class in a Filtration.
+// A class is born when its BirthSimplex enters the Filtration and dies when its DeathSimplex does.
+// Classes that never die have a nil DeathSimplex and an infinite Death.
+//
+// More info: https://en.wikipedia.org/wiki/Persistent_homology
+type PersistenceInterval struct {
+	filtration *Filtration
+
+	dim          Dim
+	birthSimplex *Simplex
+	deathSimplex *Simplex
+
+	cycle []*Simplex
+
+	Birth float64
+	Death float64
+}
+
+func (pi *PersistenceInterval) String() string {
+	return fmt.Sprintf(`PersistenceInterval{"dim": %d, "birth": %v, "death": %v}`,
+		pi.dim,
+		pi.Birth,
+		pi.Death,
+	)
+}
+
+// Dim returns the dimension of the homology class.
+func (pi *PersistenceInterval) Dim() Dim {
+	return pi.dim
+}
+
+// Persistence returns the length of the interval.
+func (pi *PersistenceInterval) Persistence() float64 {
+	return pi.Death - pi.Birth
+}
+
+// IsEssential returns true if the homology class never dies.
+func (pi *PersistenceInterval) IsEssential() bool {
+	return pi.deathSimplex == nil
+}
+
+// BirthSimplex returns the Simplex whose arrival creates the homology class.
+func (pi *PersistenceInterval) BirthSimplex() *Simplex {
+	return pi.birthSimplex
+}
+
+// DeathSimplex returns the Simplex whose arrival kills the homology class; returns nil if the class never dies.
+func (pi *PersistenceInterval) DeathSimplex() *Simplex {
+	return pi.deathSimplex
+}
+
+// Representative returns a cycle representing the homology class throughout its lifetime.
+func (pi *PersistenceInterval) Representative() *Chain {
+	cg := pi.filtration.complex.ChainGroup(pi.dim)
+
+	return cg.NewChainFromSimplices(pi.cycle...)
+}
+
+// Barcode is a collection of persistence intervals.
+type Barcode []*PersistenceInterval
+
+// PersistencePairs returns every persistence interval of dimension d, including those born and killed at the same time.
+func (f *Filtration) PersistencePairs(d Dim) []*PersistenceInterval {
+	if f.pairs == nil {
+		f.persist()
+	}
+
+	pairs := make([]*PersistenceInterval, len(f.pairs[d]))
+	copy(pairs, f.pairs[d])
+
+	return pairs
+}
+
+// Barcode returns the persistence intervals of dimension d with positive length.
+func (f *Filtration) Barcode(d Dim) Barcode {
+	barcode := Barcode{}
+
+	for _, pi := range f.PersistencePairs(d) {
+		if pi.Persistence() > 0 {
+			barcode = append(barcode, pi)
+		}
+	}
+
+	return barcode
+}
+
+// persist pairs the simplices of the Filtration by reducing its boundary matrix column by column, left to right.
+// Columns are reduced over Z_2 by the same engine BoundaryMap uses for its boundary matrix;
+// the filtration order of the rows and columns is what makes the resulting pairs meaningful.
+//
+// More info: 'Computational Topology: An Introduction' by Edelsbrunner & Harer, pg 153.
+func (f *Filtration) persist() {
+	f.pairs = map[Dim][]*PersistenceInterval{}
+
+	n := len(f.simplices)
+	r := newGF2Matrix(n, n)
+	for j, smplx := range f.simplices {
+		if d := smplx.Dim(); d > 0 {
+			for _, face := range smplx.Faces(d - 1).Slice() {
+				r.set(f.positions[face], j)
+			}
+		}
+	}
+
+	v := r.columnReduce()
+
+	paired := make([]bool, n)
+	for j := 0; j < n; j++ {
+		if low := r.low(j); low >= 0 {
+			paired[low] = true
+		}
+	}
+
+	for j, smplx := range f.simplices {
+		if low := r.low(j); low >= 0 {
+			// j kills the class created by its pivot
+			born := f.simplices[low]
+			f.pairs[born.Dim()] = append(f.pairs[born.Dim()], &PersistenceInterval{
+				filtration:   f,
+				dim:          born.Dim(),
+				birthSimplex: born,
+				deathSimplex: smplx,
+				cycle:        f.simplicesAt(r.ones(j)),
+				Birth:        f.births[born],
+				Death:        f.births[smplx],
+			})
+			continue
+		}
+
+		if paired[j] {
+			continue
+		}
+
+		// j creates a class which never dies
+		f.pairs[smplx.Dim()] = append(f.pairs[smplx.Dim()], &PersistenceInterval{
+			filtration:   f,
+			dim:          smplx.Dim(),
+			birthSimplex: smplx,
+			cycle:        f.simplicesAt(v.ones(j)),
+			Birth:        f.births[smplx],
+			Death:        math.Inf(1),
+		})
+	}
+}
+
+func (f *Filtration) simplicesAt(positions []int) []*Simplex {
+	simplices := make([]*Simplex, len(positions))
+	for idx, pos := range positions {
+		simplices[idx] = f.simplices[pos]
+	}
+
+	return simplices
+}
@@ -0,0 +1,298 @@
+package comptop
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DiagramPoint is a point of a PersistenceDiagram; Death is +Inf for a class which never dies.
+type DiagramPoint struct {
+	Birth float64
+	Death float64
+}
+
+func (p DiagramPoint) String() string {
+	return fmt.Sprintf("(%v, %v)", p.Birth, p.Death)
+}
+
+// Persistence returns the lifetime of the class the point represents.
+func (p DiagramPoint) Persistence() float64 {
+	return p.Death - p.Birth
+}
+
+// IsEssential returns true if the class the point represents never dies.
+func (p DiagramPoint) IsEssential() bool {
+	return math.IsInf(p.Death, 1)
+}
+
+// PersistenceDiagram is the multiset of (birth, death) pairs of the persistence intervals of one dimension.
+//
+// More info: https://en.wikipedia.org/wiki/Persistent_homology
+type PersistenceDiagram []DiagramPoint
+
+// Diagram returns the PersistenceDiagram of the intervals of b.
+func (b Barcode) Diagram() PersistenceDiagram {
+	pd := PersistenceDiagram{}
+	for _, pi := range b {
+		pd = append(pd, DiagramPoint{Birth: pi.Birth, Death: pi.Death})
+	}
+
+	return pd
+}
+
+// Diagram returns the PersistenceDiagram of the intervals of dimension d with positive length.
+func (f *Filtration) Diagram(d Dim) PersistenceDiagram {
+	return f.Barcode(d).Diagram()
+}
+
+// Diagrams returns the persistence diagrams of f, one per dimension.
+func (f *Filtration) Diagrams() []PersistenceDiagram {
+	diagrams := []PersistenceDiagram{}
+	for d := Dim(0); d <= f.complex.dim; d++ {
+		diagrams = append(diagrams, f.Diagram(d))
+	}
+
+	return diagrams
+}
+
+// Finite returns the points of pd which die.
+func (pd PersistenceDiagram) Finite() PersistenceDiagram {
+	finite := PersistenceDiagram{}
+	for _, p := range pd {
+		if !p.IsEssential() {
+			finite = append(finite, p)
+		}
+	}
+
+	return finite
+}
+
+// Essential returns the births of the points of pd which never die, in ascending order.
+func (pd PersistenceDiagram) Essential() []float64 {
+	births := []float64{}
+	for _, p := range pd {
+		if p.IsEssential() {
+			births = append(births, p.Birth)
+		}
+	}
+	sort.Float64s(births)
+
+	return births
+}
+
+// BottleneckDistance returns the bottleneck distance between the persistence diagrams a and b:
+// the smallest e for which there is a matching between a and b, where points may also be matched to the diagonal,
+// moving no point further than e in the L^inf norm.
+// Points which never die can only be matched with each other, so the distance is +Inf if a and b have different numbers of them.
+//
+// The distance is one of the L^inf distances between two points or between a point and the diagonal;
+// these candidates are searched with a binary search, checking each for a perfect matching of the bipartite graph of pairs close enough.
+//
+// More info: 'Computational Topology: An Introduction' by Edelsbrunner & Harer, pg 196.
+func BottleneckDistance(a, b PersistenceDiagram) float64 {
+	ea, eb := a.Essential(), b.Essential()
+	if len(ea) != len(eb) {
+		return math.Inf(1)
+	}
+
+	// Matching sorted births is optimal on the line
+	var essential float64
+	for idx := range ea {
+		essential = math.Max(essential, math.Abs(ea[idx]-eb[idx]))
+	}
+
+	fa, fb := a.Finite(), b.Finite()
+	cost := diagramCosts(fa, fb)
+
+	candidates := []float64{0}
+	for _, row := range cost {
+		candidates = append(candidates, row...)
+	}
+	sort.Float64s(candidates)
+
+	lo, hi := 0, len(candidates)-1
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if hasPerfectMatching(cost, candidates[mid]) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	return math.Max(essential, candidates[lo])
+}
+
+// WassersteinDistance returns the p-Wasserstein distance between the persistence diagrams a and b:
+// the p^th root of the smallest sum of the p^th powers of the L^inf distances moved by points over all matchings between a and b,
+// where points may also be matched to the diagonal.
+// Points which never die can only be matched with each other, so the distance is +Inf if a and b have different numbers of them.
+// WassersteinDistance panics if p < 1.
+//
+// The optimal matching is found with the Hungarian algorithm on a and b extended by the diagonal projections of each other's points.
+//
+// More info: 'Computational Topology: An Introduction' by Edelsbrunner & Harer, pg 183.
+func WassersteinDistance(a, b PersistenceDiagram, p float64) float64 {
+	if p < 1 {
+		panic(fmt.Sprintf("comptop: WassersteinDistance: p must be at least 1, got %v", p))
+	}
+
+	ea, eb := a.Essential(), b.Essential()
+	if len(ea) != len(eb) {
+		return math.Inf(1)
+	}
+
+	var total float64
+	for idx := range ea {
+		total += math.Pow(math.Abs(ea[idx]-eb[idx]), p)
+	}
+
+	cost := diagramCosts(a.Finite(), b.Finite())
+	for _, row := range cost {
+		for j := range row {
+			row[j] = math.Pow(row[j], p)
+		}
+	}
+	total += minCostMatching(cost)
+
+	return math.Pow(total, 1/p)
+}
+
+// diagramCosts returns the square matrix of L^inf distances between the points of a followed by one diagonal slot per point of b,
+// and the points of b followed by one diagonal slot per point of a.
+// Every diagonal slot is at the same distance from a given point: that of its projection onto the diagonal; two diagonal slots are at distance 0.
+func diagramCosts(a, b PersistenceDiagram) [][]float64 {
+	n := len(a) + len(b)
+
+	cost := make([][]float64, n)
+	for i := range cost {
+		cost[i] = make([]float64, n)
+	}
+
+	for i, p := range a {
+		for j, q := range b {
+			cost[i][j] = math.Max(math.Abs(p.Birth-q.Birth), math.Abs(p.Death-q.Death))
+		}
+		for j := len(b); j < n; j++ {
+			cost[i][j] = p.Persistence() / 2
+		}
+	}
+
+	for i := len(a); i < n; i++ {
+		for j, q := range b {
+			cost[i][j] = q.Persistence() / 2
+		}
+	}
+
+	return cost
+}
+
+// hasPerfectMatching returns true if the bipartite graph joining rows and columns with a cost of at most e has a perfect matching.
+// Augmenting paths are searched for one row at a time.
+func hasPerfectMatching(cost [][]float64, e float64) bool {
+	n := len(cost)
+	match := make([]int, n)
+	for j := range match {
+		match[j] = -1
+	}
+
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for j := 0; j < n; j++ {
+			if cost[i][j] > e || seen[j] {
+				continue
+			}
+			seen[j] = true
+
+			if match[j] < 0 || augment(match[j], seen) {
+				match[j] = i
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for i := 0; i < n; i++ {
+		if !augment(i, make([]bool, n)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// minCostMatching returns the smallest total cost of a perfect matching between the rows and columns of the square matrix cost.
+//
+// More info: https://en.wikipedia.org/wiki/Hungarian_algorithm
+func minCostMatching(cost [][]float64) float64 {
+	n := len(cost)
+	if n == 0 {
+		return 0
+	}
+
+	// Potentials u and v, with row match[j] matched to column j; row and column 0 are sentinels
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	match := make([]int, n+1)
+	way := make([]int, n+1)
+
+	for i := 1; i <= n; i++ {
+		match[0] = i
+		j0 := 0
+
+		minv := make([]float64, n+1)
+		used := make([]bool, n+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+
+		for match[j0] != 0 {
+			used[j0] = true
+			i0 := match[j0]
+			delta := math.Inf(1)
+			j1 := 0
+
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+
+				if cur := cost[i0-1][j-1] - u[i0] - v[j]; cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[match[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+
+			j0 = j1
+		}
+
+		// Flip the augmenting path
+		for j0 != 0 {
+			j1 := way[j0]
+			match[j0] = match[j1]
+			j0 = j1
+		}
+	}
+
+	var total float64
+	for j := 1; j <= n; j++ {
+		total += cost[match[j]-1][j-1]
+	}
+
+	return total
+}
@@ -0,0 +1,68 @@
+package comptop
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBottleneckDistance(t *testing.T) {
+	inf := math.Inf(1)
+
+	table := []struct {
+		name               string
+		a, b               PersistenceDiagram
+		bottleneck, w1, w2 float64
+	}{
+		{"empty", PersistenceDiagram{}, PersistenceDiagram{}, 0, 0, 0},
+		{"identical", PersistenceDiagram{{0, 2}, {1, 3}}, PersistenceDiagram{{1, 3}, {0, 2}}, 0, 0, 0},
+		{"shifted", PersistenceDiagram{{0, 2}}, PersistenceDiagram{{0, 3}}, 1, 1, 1},
+		{"to the diagonal", PersistenceDiagram{{0, 2}}, PersistenceDiagram{}, 1, 1, 1},
+		{"mixed", PersistenceDiagram{{0, 10}, {1, 2}}, PersistenceDiagram{{0, 10.5}}, 0.5, 1, math.Sqrt(0.5)},
+		{"essential", PersistenceDiagram{{0, inf}, {0, 1}}, PersistenceDiagram{{1, inf}, {0, 1}}, 1, 1, 1},
+		{"essential mismatch", PersistenceDiagram{{0, inf}, {2, inf}}, PersistenceDiagram{{0, inf}}, inf, inf, inf},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(tt *testing.T) {
+			for _, pair := range [][2]PersistenceDiagram{{row.a, row.b}, {row.b, row.a}} {
+				if d := BottleneckDistance(pair[0], pair[1]); math.Abs(d-row.bottleneck) > 1e-9 && d != row.bottleneck {
+					tt.Errorf("expected a bottleneck distance of %v, got %v", row.bottleneck, d)
+				}
+				if d := WassersteinDistance(pair[0], pair[1], 1); math.Abs(d-row.w1) > 1e-9 && d != row.w1 {
+					tt.Errorf("expected a 1-Wasserstein distance of %v, got %v", row.w1, d)
+				}
+				if d := WassersteinDistance(pair[0], pair[1], 2); math.Abs(d-row.w2) > 1e-9 && d != row.w2 {
+					tt.Errorf("expected a 2-Wasserstein distance of %v, got %v", row.w2, d)
+				}
+			}
+		})
+	}
+}
+
+func TestFiltration_Diagram(t *testing.T) {
+	square := func(side float64) *Filtration {
+		points := [][]float64{{0, 0}, {side, 0}, {side, side}, {0, side}}
+		c := RipsComplex(points, 2*side, 3)
+		return c.Filtration(func(s *Simplex) float64 {
+			return s.Data.(float64)
+		})
+	}
+
+	small, large := square(1), square(2)
+
+	// Components die at 1 and 2, the hole lives on [1, sqrt(2)) and [2, 2 sqrt(2))
+	expected := []float64{1, math.Sqrt2 - 1}
+	for d, e := range expected {
+		a, b := small.Diagram(Dim(d)), large.Diagram(Dim(d))
+		if dist := BottleneckDistance(a, a); dist != 0 {
+			t.Errorf("expected a diagram to be at distance 0 from itself, got %v", dist)
+		}
+		if dist := BottleneckDistance(a, b); math.Abs(dist-e) > 1e-9 {
+			t.Errorf("expected a bottleneck distance of %v in dimension %d, got %v", e, d, dist)
+		}
+	}
+
+	if count := len(small.Diagrams()); count != 4 {
+		t.Errorf("expected 4 diagrams, got %d", count)
+	}
+}
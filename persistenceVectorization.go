@@ -0,0 +1,289 @@
+package comptop
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// LinearGrid returns n evenly spaced values from min to max, inclusive.
+// LinearGrid panics if n < 2.
+func LinearGrid(min, max float64, n int) []float64 {
+	if n < 2 {
+		panic(fmt.Sprintf("comptop: LinearGrid: need at least 2 values, got %d", n))
+	}
+
+	grid := make([]float64, n)
+	step := (max - min) / float64(n-1)
+	for idx := range grid {
+		grid[idx] = min + float64(idx)*step
+	}
+	grid[n-1] = max
+
+	return grid
+}
+
+// BettiCurve returns the number of points of pd alive at each time in grid, a point (b, d) being alive at times t with b <= t < d.
+func (pd PersistenceDiagram) BettiCurve(grid []float64) *mat.VecDense {
+	curve := mat.NewVecDense(len(grid), nil)
+	for idx, t := range grid {
+		var alive int
+		for _, p := range pd {
+			if p.Birth <= t && t < p.Death {
+				alive++
+			}
+		}
+		curve.SetVec(idx, float64(alive))
+	}
+
+	return curve
+}
+
+// PersistenceLandscape is a persistence landscape sampled on a grid.
+// The k^th landscape function is lambda_k(t) = k^th largest value of max(0, min(t - b, d - t)) over the points (b, d) of a diagram.
+//
+// More info: 'Statistical topological data analysis using persistence landscapes' by Bubenik.
+type PersistenceLandscape struct {
+	grid   []float64
+	values *mat.Dense
+}
+
+// Landscape returns the first levels landscape functions of pd sampled at the times in grid.
+// Points which never die have unbounded tents and are left out.
+// Landscape panics if levels < 1 or grid is empty.
+func (pd PersistenceDiagram) Landscape(levels int, grid []float64) *PersistenceLandscape {
+	if levels < 1 || len(grid) == 0 {
+		panic(fmt.Sprintf("comptop: Landscape: need at least one level and one time, got %d and %d", levels, len(grid)))
+	}
+
+	pl := &PersistenceLandscape{
+		grid:   append([]float64(nil), grid...),
+		values: mat.NewDense(levels, len(grid), nil),
+	}
+
+	finite := pd.Finite()
+	tents := make([]float64, len(finite))
+	for col, t := range grid {
+		for idx, p := range finite {
+			tents[idx] = math.Max(0, math.Min(t-p.Birth, p.Death-t))
+		}
+		sort.Sort(sort.Reverse(sort.Float64Slice(tents)))
+
+		for k := 0; k < levels && k < len(tents); k++ {
+			pl.values.Set(k, col, tents[k])
+		}
+	}
+
+	return pl
+}
+
+// Grid returns the times pl is sampled at.
+func (pl *PersistenceLandscape) Grid() []float64 {
+	return append([]float64(nil), pl.grid...)
+}
+
+// Levels returns the number of landscape functions in pl.
+func (pl *PersistenceLandscape) Levels() int {
+	levels, _ := pl.values.Dims()
+	return levels
+}
+
+// Values returns the matrix whose k^th row holds the samples of the (k+1)^th landscape function.
+func (pl *PersistenceLandscape) Values() *mat.Dense {
+	return mat.DenseCopyOf(pl.values)
+}
+
+// Level returns the samples of the k^th landscape function, counting from 1.
+func (pl *PersistenceLandscape) Level(k int) *mat.VecDense {
+	return mat.VecDenseCopyOf(pl.values.RowView(k - 1))
+}
+
+// Vector returns the samples of every landscape function of pl, one level after the other.
+func (pl *PersistenceLandscape) Vector() *mat.VecDense {
+	levels, n := pl.values.Dims()
+	v := mat.NewVecDense(levels*n, nil)
+	for k := 0; k < levels; k++ {
+		for col := 0; col < n; col++ {
+			v.SetVec(k*n+col, pl.values.At(k, col))
+		}
+	}
+
+	return v
+}
+
+// Norm returns the L^p norm of pl: the p^th root of the sum over levels of the integral of |lambda_k|^p, using the trapezoidal rule on the grid.
+// If p is +Inf, the largest sample is returned.
+// Norm panics if p < 1.
+func (pl *PersistenceLandscape) Norm(p float64) float64 {
+	if p < 1 {
+		panic(fmt.Sprintf("comptop: PersistenceLandscape.Norm: p must be at least 1, got %v", p))
+	}
+
+	if math.IsInf(p, 1) {
+		return mat.Max(pl.values)
+	}
+
+	var total float64
+	levels, n := pl.values.Dims()
+	for k := 0; k < levels; k++ {
+		for col := 1; col < n; col++ {
+			a := math.Pow(pl.values.At(k, col-1), p)
+			b := math.Pow(pl.values.At(k, col), p)
+			total += (a + b) / 2 * (pl.grid[col] - pl.grid[col-1])
+		}
+	}
+
+	return math.Pow(total, 1/p)
+}
+
+// AverageLandscape returns the mean of the landscapes, level by level.
+// Landscapes with fewer levels count as 0 on the missing levels.
+// AverageLandscape panics if the landscapes are not sampled on the same grid.
+func AverageLandscape(landscapes ...*PersistenceLandscape) *PersistenceLandscape {
+	if len(landscapes) == 0 {
+		return nil
+	}
+
+	grid := landscapes[0].grid
+	levels := 0
+	for _, pl := range landscapes {
+		if len(pl.grid) != len(grid) {
+			panic("comptop: AverageLandscape: landscapes are sampled on different grids")
+		}
+		for idx, t := range pl.grid {
+			if t != grid[idx] {
+				panic("comptop: AverageLandscape: landscapes are sampled on different grids")
+			}
+		}
+
+		if l := pl.Levels(); l > levels {
+			levels = l
+		}
+	}
+
+	avg := &PersistenceLandscape{
+		grid:   append([]float64(nil), grid...),
+		values: mat.NewDense(levels, len(grid), nil),
+	}
+
+	for _, pl := range landscapes {
+		l, n := pl.values.Dims()
+		for k := 0; k < l; k++ {
+			for col := 0; col < n; col++ {
+				avg.values.Set(k, col, avg.values.At(k, col)+pl.values.At(k, col))
+			}
+		}
+	}
+	avg.values.Scale(1/float64(len(landscapes)), avg.values)
+
+	return avg
+}
+
+// PersistenceImageConfig configures PersistenceImage.
+// Points (b, d) are moved to (b, d - b) in birth-persistence coordinates, weighted, and spread out by a Gaussian kernel,
+// which is then integrated over each pixel of a grid covering BirthRange by PersistenceRange.
+type PersistenceImageConfig struct {
+	// Width and Height are the number of pixels along the birth and persistence axes.
+	Width, Height int
+
+	// BirthRange and PersistenceRange are the bounds of the image; if a range is empty, it is set to cover the points of the diagram.
+	BirthRange       [2]float64
+	PersistenceRange [2]float64
+
+	// Sigma is the standard deviation of the Gaussian kernel; if 0, it is a tenth of the largest side of the image.
+	Sigma float64
+
+	// Weight gives the weight of each point; if nil, points are weighted by their persistence divided by the largest persistence.
+	// Weights should vanish on the diagonal so that the image is stable.
+	Weight func(DiagramPoint) float64
+}
+
+// PersistenceImage returns the persistence image of pd as a matrix with Height rows and Width columns;
+// row 0 holds the lowest persistences and column 0 the earliest births.
+// Points which never die are left out.
+// PersistenceImage panics if the config has no pixels.
+//
+// More info: 'Persistence images: a stable vector representation of persistent homology' by Adams et al.
+func (pd PersistenceDiagram) PersistenceImage(config PersistenceImageConfig) *mat.Dense {
+	if config.Width < 1 || config.Height < 1 {
+		panic(fmt.Sprintf("comptop: PersistenceImage: the image needs at least one pixel, got %d by %d", config.Width, config.Height))
+	}
+
+	finite := pd.Finite()
+
+	births, pers := config.BirthRange, config.PersistenceRange
+	if births[0] >= births[1] || pers[0] >= pers[1] {
+		autoBirths := [2]float64{math.Inf(1), math.Inf(-1)}
+		autoPers := [2]float64{0, math.Inf(-1)}
+		for _, p := range finite {
+			autoBirths[0] = math.Min(autoBirths[0], p.Birth)
+			autoBirths[1] = math.Max(autoBirths[1], p.Birth)
+			autoPers[1] = math.Max(autoPers[1], p.Persistence())
+		}
+
+		// Keep a non-empty range around a single point, or no points at all
+		for _, r := range []*[2]float64{&autoBirths, &autoPers} {
+			if math.IsInf(r[0], 0) || math.IsInf(r[1], 0) {
+				r[0], r[1] = 0, 1
+			}
+			if r[0] >= r[1] {
+				r[1] = r[0] + 1
+			}
+		}
+
+		if births[0] >= births[1] {
+			births = autoBirths
+		}
+		if pers[0] >= pers[1] {
+			pers = autoPers
+		}
+	}
+
+	sigma := config.Sigma
+	if sigma <= 0 {
+		sigma = math.Max(births[1]-births[0], pers[1]-pers[0]) / 10
+	}
+
+	weight := config.Weight
+	if weight == nil {
+		var maxPers float64
+		for _, p := range finite {
+			maxPers = math.Max(maxPers, p.Persistence())
+		}
+		weight = func(p DiagramPoint) float64 {
+			if maxPers == 0 {
+				return 0
+			}
+			return p.Persistence() / maxPers
+		}
+	}
+
+	xs := LinearGrid(births[0], births[1], config.Width+1)
+	ys := LinearGrid(pers[0], pers[1], config.Height+1)
+
+	// The integral of a Gaussian over [a, b] is a difference of error functions
+	mass := func(a, b, mu float64) float64 {
+		s := sigma * math.Sqrt2
+		return (math.Erf((b-mu)/s) - math.Erf((a-mu)/s)) / 2
+	}
+
+	img := mat.NewDense(config.Height, config.Width, nil)
+	for _, p := range finite {
+		w := weight(p)
+		if w == 0 {
+			continue
+		}
+
+		for row := 0; row < config.Height; row++ {
+			py := mass(ys[row], ys[row+1], p.Persistence())
+			for col := 0; col < config.Width; col++ {
+				px := mass(xs[col], xs[col+1], p.Birth)
+				img.Set(row, col, img.At(row, col)+w*px*py)
+			}
+		}
+	}
+
+	return img
+}
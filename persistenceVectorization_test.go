@@ -0,0 +1,100 @@
+package comptop
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestPersistenceDiagram_BettiCurve(t *testing.T) {
+	pd := PersistenceDiagram{{0, 2}, {1, 3}, {0, math.Inf(1)}}
+
+	curve := pd.BettiCurve(LinearGrid(0, 3, 4))
+	expected := mat.NewVecDense(4, []float64{2, 3, 2, 1})
+	if !mat.Equal(curve, expected) {
+		t.Errorf("expected Betti curve %v, got %v", mat.Formatted(expected.T()), mat.Formatted(curve.T()))
+	}
+}
+
+func TestPersistenceDiagram_Landscape(t *testing.T) {
+	pd := PersistenceDiagram{{0, 2}, {1, 3}, {0, math.Inf(1)}}
+	grid := LinearGrid(0, 3, 7)
+
+	pl := pd.Landscape(3, grid)
+	expected := mat.NewDense(3, 7, []float64{
+		0, 0.5, 1, 0.5, 1, 0.5, 0,
+		0, 0, 0, 0.5, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0,
+	})
+	if !mat.EqualApprox(pl.Values(), expected, 1e-12) {
+		t.Fatalf("expected landscape\n%v\ngot\n%v", mat.Formatted(expected), mat.Formatted(pl.Values()))
+	}
+
+	if n := pl.Norm(math.Inf(1)); n != 1 {
+		t.Errorf("expected a sup norm of 1, got %v", n)
+	}
+
+	// Each tent has area 1, and the second level is a tent of area 1/4
+	single := PersistenceDiagram{{0, 2}}.Landscape(1, grid)
+	if n := single.Norm(1); math.Abs(n-1) > 1e-12 {
+		t.Errorf("expected an L^1 norm of 1, got %v", n)
+	}
+
+	avg := AverageLandscape(single, PersistenceDiagram{}.Landscape(2, grid))
+	if avg.Levels() != 2 {
+		t.Fatalf("expected 2 levels, got %d", avg.Levels())
+	}
+	half := mat.NewVecDense(7, nil)
+	half.ScaleVec(0.5, single.Level(1))
+	if !mat.EqualApprox(avg.Level(1), half, 1e-12) {
+		t.Errorf("expected the average to halve the landscape, got %v", mat.Formatted(avg.Level(1).T()))
+	}
+	if avg.Vector().Len() != 14 {
+		t.Errorf("expected a vector of 14 samples, got %d", avg.Vector().Len())
+	}
+}
+
+func TestPersistenceDiagram_PersistenceImage(t *testing.T) {
+	pd := PersistenceDiagram{{0, 1}, {0, math.Inf(1)}}
+
+	img := pd.PersistenceImage(PersistenceImageConfig{
+		Width:            20,
+		Height:           20,
+		BirthRange:       [2]float64{-5, 5},
+		PersistenceRange: [2]float64{-4, 6},
+		Sigma:            0.5,
+	})
+
+	// The whole kernel fits in the image, carrying the weight 1 of the only finite point
+	if total := mat.Sum(img); math.Abs(total-1) > 1e-6 {
+		t.Errorf("expected a total mass of 1, got %v", total)
+	}
+
+	// The point (0, 1) sits at the corner of pixels (9, 10) and (10, 10)
+	r, c := img.Dims()
+	var bestRow, bestCol int
+	for row := 0; row < r; row++ {
+		for col := 0; col < c; col++ {
+			if img.At(row, col) > img.At(bestRow, bestCol) {
+				bestRow, bestCol = row, col
+			}
+		}
+	}
+	if (bestRow != 9 && bestRow != 10) || (bestCol != 9 && bestCol != 10) {
+		t.Errorf("expected the brightest pixel next to (10, 10), got (%d, %d)", bestRow, bestCol)
+	}
+
+	// Automatic ranges and a custom weight
+	auto := PersistenceDiagram{{0, 1}, {1, 3}}.PersistenceImage(PersistenceImageConfig{
+		Width:  5,
+		Height: 4,
+		Weight: func(DiagramPoint) float64 { return 2 },
+	})
+	if rows, cols := auto.Dims(); rows != 4 || cols != 5 {
+		t.Errorf("expected a 4 by 5 image, got %d by %d", rows, cols)
+	}
+	if total := mat.Sum(auto); total <= 0 || total > 4 {
+		t.Errorf("expected a total mass in (0, 4], got %v", total)
+	}
+}
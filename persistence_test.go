@@ -0,0 +1,66 @@
+package comptop
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFiltration_Barcode(t *testing.T) {
+	// The corners of the unit square
+	points := [][]float64{
+		{0, 0}, {1, 0}, {1, 1}, {0, 1},
+	}
+
+	c := RipsComplex(points, 2, 3)
+	f := c.Filtration(func(s *Simplex) float64 {
+		return s.Data.(float64)
+	})
+
+	// Three components merge when the sides appear, the fourth lives forever.
+	b0 := f.Barcode(0)
+	if count := len(b0); count != 4 {
+		t.Fatalf("expected 4 intervals in dimension 0, got %d: %v", count, b0)
+	}
+
+	var essential int
+	for _, pi := range b0 {
+		if pi.IsEssential() {
+			essential++
+			continue
+		}
+		if pi.Birth != 0 || pi.Death != 1 {
+			t.Errorf("expected interval [0, 1), got %v", pi)
+		}
+	}
+	if essential != 1 {
+		t.Errorf("expected 1 essential class in dimension 0, got %d", essential)
+	}
+
+	// The square's hole is born with its last side and filled in when the diagonals appear.
+	b1 := f.Barcode(1)
+	if count := len(b1); count != 1 {
+		t.Fatalf("expected 1 interval in dimension 1, got %d: %v", count, b1)
+	}
+
+	pi := b1[0]
+	if pi.Birth != 1 || math.Abs(pi.Death-math.Sqrt2) > 1e-9 {
+		t.Errorf("expected interval [1, sqrt(2)), got %v", pi)
+	}
+
+	rep := pi.Representative()
+	if rep.IsZero() {
+		t.Fatal("expected a non-zero representative")
+	}
+	if bndry := rep.Boundary(); !bndry.IsZero() {
+		t.Errorf("expected representative to be a cycle, got boundary %v", bndry)
+	}
+	for _, smplx := range rep.Simplices() {
+		if f.Birth(smplx) > pi.Birth {
+			t.Errorf("representative contains %v, born after the class", smplx)
+		}
+	}
+
+	if count := len(f.Barcode(2)); count != 0 {
+		t.Errorf("expected no intervals in dimension 2, got %d", count)
+	}
+}
@@ -0,0 +1,385 @@
+package comptop
+
+import (
+	"math/big"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Pair is a Complex K together with a subcomplex L, written (K, L).
+// The relative chain group C_p(K, L) is the quotient C_p(K) / C_p(L); its basis is made up of the p-simplices of K which are not in L.
+// Relative homology H_p(K, L) measures the holes of K which are not already holes of L, counting cycles whose boundary lies in L.
+//
+// A Pair is computed from K as it is when the Pair is made; simplices added to K afterwards are not reflected.
+//
+// More info: https://en.wikipedia.org/wiki/Relative_homology
+type Pair struct {
+	complex *Complex
+	sub     map[*Simplex]struct{}
+
+	chainGroups map[Dim]*RelativeChainGroup
+	groups      map[Dim]*RelativeHomologyGroup
+}
+
+// RelativeTo returns the pair (c, L) where L is the smallest subcomplex of c containing every Simplex in l (along with all of their faces).
+// Simplices in l which do not belong to c are ignored.
+func (c *Complex) RelativeTo(l *SimplicialSet) *Pair {
+	p := &Pair{
+		complex:     c,
+		sub:         map[*Simplex]struct{}{},
+		chainGroups: map[Dim]*RelativeChainGroup{},
+		groups:      map[Dim]*RelativeHomologyGroup{},
+	}
+
+	if l == nil {
+		return p
+	}
+
+	for smplx := range l.set {
+		if smplx.complex != c {
+			continue
+		}
+
+		p.sub[smplx] = struct{}{}
+		for d := Dim(0); d < smplx.Dim(); d++ {
+			for face := range smplx.Faces(d).set {
+				p.sub[face] = struct{}{}
+			}
+		}
+	}
+
+	return p
+}
+
+// RelativeToComplex returns the pair (c, L) where L is made up of the simplices of c sharing a base with a Simplex of l.
+func (c *Complex) RelativeToComplex(l *Complex) *Pair {
+	set := NewSimplicialSet()
+
+	if l != nil {
+		for d := Dim(0); d <= l.dim; d++ {
+			for _, smplx := range l.GetdSimplices(d) {
+				if s := c.GetSimplex(smplx.Base()...); s != nil {
+					set.Add(s)
+				}
+			}
+		}
+	}
+
+	return c.RelativeTo(set)
+}
+
+// Complex returns the ambient Complex K of the pair (K, L).
+func (p *Pair) Complex() *Complex {
+	return p.complex
+}
+
+// Subcomplex returns the simplices of the subcomplex L of the pair (K, L).
+func (p *Pair) Subcomplex() *SimplicialSet {
+	set := NewSimplicialSet()
+	for smplx := range p.sub {
+		set.Add(smplx)
+	}
+
+	return set
+}
+
+// InSubcomplex returns true if s is in the subcomplex L of the pair (K, L).
+func (p *Pair) InSubcomplex(s *Simplex) bool {
+	_, exists := p.sub[s]
+	return exists
+}
+
+// RelativeChainGroup is the relative chain group C_p(K, L) = C_p(K) / C_p(L).
+// Relative chains are represented by chains in the ChainGroup of K which have no simplices in L.
+type RelativeChainGroup struct {
+	pair *Pair
+	dim  Dim
+
+	// simplices are the p-simplices of K not in L, ordered by Index
+	simplices []*Simplex
+	rows      map[*Simplex]int
+}
+
+// ChainGroup returns the relative chain group of dimension d; returns nil if K has no simplices of dimension d.
+func (p *Pair) ChainGroup(d Dim) *RelativeChainGroup {
+	if rcg, exists := p.chainGroups[d]; exists {
+		return rcg
+	}
+
+	group := p.complex.ChainGroup(d)
+	if group == nil {
+		return nil
+	}
+
+	rcg := &RelativeChainGroup{
+		pair:      p,
+		dim:       d,
+		simplices: []*Simplex{},
+		rows:      map[*Simplex]int{},
+	}
+
+	group.sortIdxs()
+	for _, idx := range group.idxs {
+		smplx := group.Simplex(idx)
+		if p.InSubcomplex(smplx) {
+			continue
+		}
+
+		rcg.rows[smplx] = len(rcg.simplices)
+		rcg.simplices = append(rcg.simplices, smplx)
+	}
+
+	p.chainGroups[d] = rcg
+
+	return rcg
+}
+
+// Dim returns the dimension of the relative chain group.
+func (rcg *RelativeChainGroup) Dim() Dim {
+	return rcg.dim
+}
+
+// Rank returns the number of p-simplices of K which are not in L.
+func (rcg *RelativeChainGroup) Rank() int {
+	return len(rcg.simplices)
+}
+
+// Simplices returns the p-simplices of K which are not in L, ordered by Index.
+func (rcg *RelativeChainGroup) Simplices() []*Simplex {
+	simplices := make([]*Simplex, len(rcg.simplices))
+	copy(simplices, rcg.simplices)
+
+	return simplices
+}
+
+// Project returns the relative chain represented by c, which is c with its simplices in L removed.
+func (rcg *RelativeChainGroup) Project(c *Chain) *Chain {
+	group := rcg.pair.complex.ChainGroup(rcg.dim)
+	if c == nil || c.dim != rcg.dim {
+		return nil
+	}
+
+	coeffs := map[*Simplex]*big.Rat{}
+	for _, smplx := range c.simplices {
+		if !rcg.pair.InSubcomplex(smplx) {
+			coeffs[smplx] = c.Coefficient(smplx)
+		}
+	}
+
+	return group.NewChainFromCoefficients(coeffs)
+}
+
+// Boundary returns the relative boundary of c: the boundary of c in K with its simplices in L removed.
+func (rcg *RelativeChainGroup) Boundary(c *Chain) *Chain {
+	if rcg.dim == 0 || c == nil {
+		return nil
+	}
+
+	c = rcg.Project(c)
+
+	f := rcg.pair.complex.Field()
+	coeffs := map[*Simplex]*big.Rat{}
+	for _, smplx := range c.simplices {
+		x := c.Coefficient(smplx)
+		for _, face := range smplx.Faces(rcg.dim - 1).Slice() {
+			if rcg.pair.InSubcomplex(face) {
+				continue
+			}
+
+			y := fieldMul(f, x, big.NewRat(int64(smplx.faceSign(&face.simplex)), 1))
+			if z, exists := coeffs[face]; exists {
+				y = fieldAdd(f, y, z)
+			}
+			coeffs[face] = y
+		}
+	}
+
+	return rcg.pair.complex.ChainGroup(rcg.dim - 1).NewChainFromCoefficients(coeffs)
+}
+
+// boundaryMap returns the matrix of the relative boundary map from C_p(K, L) to C_{p-1}(K, L); returns nil if it is empty.
+func (rcg *RelativeChainGroup) boundaryMap() *BoundaryMap {
+	if rcg.dim == 0 {
+		return nil
+	}
+
+	lowerGroup := rcg.pair.ChainGroup(rcg.dim - 1)
+	m, n := lowerGroup.Rank(), rcg.Rank()
+	if m == 0 || n == 0 {
+		return nil
+	}
+
+	f := rcg.pair.complex.field
+
+	entries := func(set func(row, col, sign int)) {
+		for col, smplx := range rcg.simplices {
+			for _, face := range smplx.Faces(rcg.dim - 1).Slice() {
+				if row, exists := lowerGroup.rows[face]; exists {
+					set(row, col, smplx.faceSign(&face.simplex))
+				}
+			}
+		}
+	}
+
+	if isBinary(f) {
+		d := newGF2Matrix(m, n)
+		entries(func(row, col, sign int) {
+			d.set(row, col)
+		})
+
+		return &BoundaryMap{
+			d:     d,
+			field: f,
+		}
+	}
+
+	signed := mat.NewDense(m, n, nil)
+	entries(func(row, col, sign int) {
+		signed.Set(row, col, float64(sign))
+	})
+
+	return &BoundaryMap{
+		mat:    signed,
+		signed: signed,
+		field:  f,
+	}
+}
+
+// chainFromColumn returns the chain in K whose coefficients on the simplices of rcg are given by col.
+func (rcg *RelativeChainGroup) chainFromColumn(col []*big.Rat) *Chain {
+	coeffs := map[*Simplex]*big.Rat{}
+	for row, x := range col {
+		coeffs[rcg.simplices[row]] = x
+	}
+
+	return rcg.pair.complex.ChainGroup(rcg.dim).NewChainFromCoefficients(coeffs)
+}
+
+// column returns the coefficients of c on the simplices of rcg.
+func (rcg *RelativeChainGroup) column(c *Chain) []*big.Rat {
+	col := make([]*big.Rat, len(rcg.simplices))
+	for row, smplx := range rcg.simplices {
+		col[row] = c.Coefficient(smplx)
+	}
+
+	return col
+}
+
+// RelativeHomologyGroup H_p(K, L) is the quotient of the relative cycles (chains whose boundary lies in L)
+// by the relative boundaries (chains which differ from a boundary by a chain in L).
+type RelativeHomologyGroup struct {
+	chainGroup *RelativeChainGroup
+
+	cycles     []*Chain
+	boundaries []*Chain
+	basis      []*Chain
+}
+
+// HomologyGroup returns the relative homology group of dimension d; returns nil if K has no simplices of dimension d.
+func (p *Pair) HomologyGroup(d Dim) *RelativeHomologyGroup {
+	if rhg, exists := p.groups[d]; exists {
+		return rhg
+	}
+
+	rcg := p.ChainGroup(d)
+	if rcg == nil {
+		return nil
+	}
+
+	rhg := &RelativeHomologyGroup{
+		chainGroup: rcg,
+		cycles:     []*Chain{},
+		boundaries: []*Chain{},
+	}
+
+	// Relative cycles span the kernel of the relative boundary map from dimension p
+	if bm := rcg.boundaryMap(); bm == nil {
+		for _, smplx := range rcg.simplices {
+			rhg.cycles = append(rhg.cycles, p.complex.ChainGroup(d).NewChainFromSimplices(smplx))
+		}
+	} else {
+		for _, col := range bm.kernelBasis() {
+			rhg.cycles = append(rhg.cycles, rcg.chainFromColumn(col))
+		}
+	}
+
+	// Relative boundaries span the image of the relative boundary map from dimension p+1
+	if higherGroup := p.ChainGroup(d + 1); higherGroup != nil {
+		if bm := higherGroup.boundaryMap(); bm != nil {
+			for _, col := range bm.imageBasis() {
+				rhg.boundaries = append(rhg.boundaries, rcg.chainFromColumn(col))
+			}
+		}
+	}
+
+	p.groups[d] = rhg
+
+	return rhg
+}
+
+// HomologyGroups returns the relative homology groups of the pair in dimensions 0 to p where p is the dimension of K.
+func (p *Pair) HomologyGroups() []*RelativeHomologyGroup {
+	groups := []*RelativeHomologyGroup{}
+
+	for d := Dim(0); d <= p.complex.dim; d++ {
+		groups = append(groups, p.HomologyGroup(d))
+	}
+
+	return groups
+}
+
+// BettiNumbers returns the ranks of the relative homology groups of the pair in dimensions 0 to p where p is the dimension of K.
+func (p *Pair) BettiNumbers() []int {
+	bn := []int{}
+	for _, rhg := range p.HomologyGroups() {
+		bn = append(bn, rhg.Rank())
+	}
+
+	return bn
+}
+
+// Dim returns the dimension of the relative homology group.
+func (rhg *RelativeHomologyGroup) Dim() Dim {
+	return rhg.chainGroup.dim
+}
+
+// ChainGroup returns the relative chain group whose homology is represented.
+func (rhg *RelativeHomologyGroup) ChainGroup() *RelativeChainGroup {
+	return rhg.chainGroup
+}
+
+// Rank returns the rank of the relative homology group: the rank of the relative cycles minus the rank of the relative boundaries.
+func (rhg *RelativeHomologyGroup) Rank() int {
+	return len(rhg.cycles) - len(rhg.boundaries)
+}
+
+// Cycles returns a basis for the relative cycles: chains in K, with no simplices in L, whose boundary lies in L.
+func (rhg *RelativeHomologyGroup) Cycles() []*Chain {
+	return rhg.cycles
+}
+
+// Basis returns relative cycles whose classes form a basis for the relative homology group.
+// Each is a Chain in the ambient ChainGroup of K with no simplices in L.
+func (rhg *RelativeHomologyGroup) Basis() []*Chain {
+	if rhg.basis != nil {
+		return rhg.basis
+	}
+
+	rcg := rhg.chainGroup
+	f := rcg.pair.complex.Field()
+	span := [][]*big.Rat{}
+	for _, chain := range rhg.boundaries {
+		span = append(span, rcg.column(chain))
+	}
+	cols := [][]*big.Rat{}
+	for _, chain := range rhg.cycles {
+		cols = append(cols, rcg.column(chain))
+	}
+
+	rhg.basis = []*Chain{}
+	for _, j := range independentExtension(f, span, cols) {
+		rhg.basis = append(rhg.basis, rhg.cycles[j])
+	}
+
+	return rhg.basis
+}
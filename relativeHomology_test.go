@@ -0,0 +1,76 @@
+package comptop
+
+import "testing"
+
+func TestPair_HomologyGroups(t *testing.T) {
+	type testcase struct {
+		name       string
+		bases      []Base
+		field      Field
+		expectedBN []int
+	}
+
+	disk := []Base{{0, 1, 4}, {1, 2, 4}, {2, 3, 4}, {0, 3, 4}}
+	annulus := []Base{
+		{0, 1, 4}, {1, 4, 5}, {1, 2, 5}, {2, 5, 6},
+		{2, 3, 6}, {3, 6, 7}, {0, 3, 7}, {0, 4, 7},
+	}
+
+	tests := []testcase{
+		{name: "disk over Z_2", bases: disk, field: Z2, expectedBN: []int{0, 0, 1}},
+		{name: "disk over Q", bases: disk, field: Q, expectedBN: []int{0, 0, 1}},
+		{name: "annulus over Z_2", bases: annulus, field: Z2, expectedBN: []int{0, 1, 1}},
+		{name: "annulus over Q", bases: annulus, field: Q, expectedBN: []int{0, 1, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			c := &Complex{}
+			c.SetField(test.field)
+			c.NewSimplices(test.bases...)
+
+			pair := c.RelativeToComplex(c.BoundaryComplex())
+
+			bn := pair.BettiNumbers()
+			if len(test.expectedBN) != len(bn) {
+				tt.Fatalf("invalid number of Betti numbers: %v", bn)
+			}
+			for idx, ebn := range test.expectedBN {
+				if bn[idx] != ebn {
+					tt.Fatalf("Betti number %d is wrong; expected %d received %d", idx, ebn, bn[idx])
+				}
+			}
+
+			for _, rhg := range pair.HomologyGroups() {
+				basis := rhg.Basis()
+				if len(basis) != rhg.Rank() {
+					tt.Fatalf("expected %d relative cycles in the basis, got %d", rhg.Rank(), len(basis))
+				}
+
+				for _, chain := range basis {
+					for _, smplx := range chain.Simplices() {
+						if pair.InSubcomplex(smplx) {
+							tt.Fatalf("relative cycle %v contains %v from the subcomplex", chain, smplx)
+						}
+					}
+
+					if rhg.Dim() > 0 {
+						if boundary := rhg.ChainGroup().Boundary(chain); boundary.Len() != 0 {
+							tt.Fatalf("expected %v to be a relative cycle, its relative boundary is %v", chain, boundary)
+						}
+					}
+				}
+			}
+		})
+	}
+
+	// Relative to the empty subcomplex, relative homology is just homology
+	c := &Complex{}
+	c.NewSimplices(annulus...)
+	bn := c.RelativeTo(nil).BettiNumbers()
+	for idx, ebn := range c.BettiNumbers() {
+		if bn[idx] != ebn {
+			t.Fatalf("Betti number %d is wrong; expected %d received %d", idx, ebn, bn[idx])
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package comptop
+
+// RemoveSimplex removes s from c, along with every Simplex that has s as a face, so that c remains a simplicial complex.
+// The removed simplices are returned, detached from c: they no longer belong to any Complex, so removing them again does nothing.
+// The remaining simplices of each ChainGroup are re-indexed, so chains created before the removal should not be used afterwards.
+func (c *Complex) RemoveSimplex(s *Simplex) *SimplicialSet {
+	if s == nil || s.complex != c {
+		return NewSimplicialSet()
+	}
+
+	removed := NewSimplicialSet(s)
+	if cf := s.AllCofaces(); cf != nil {
+		removed.Add(cf.Slice()...)
+	}
+
+	c.remove(removed)
+
+	return removed
+}
+
+// RemoveVertex removes the 0-simplex with index idx from c, along with every Simplex it is a vertex of.
+// The removed simplices are returned.
+func (c *Complex) RemoveVertex(idx Index) *SimplicialSet {
+	s := c.GetSimplex(idx)
+	if s == nil {
+		return NewSimplicialSet()
+	}
+
+	return c.RemoveSimplex(s)
+}
+
+// RemoveSimplices removes every Simplex for which pred returns true from c, along with all of their cofaces.
+// The removed simplices are returned.
+func (c *Complex) RemoveSimplices(pred func(*Simplex) bool) *SimplicialSet {
+	removed := NewSimplicialSet()
+
+	for d := Dim(0); d <= c.dim; d++ {
+		for _, smplx := range c.GetdSimplices(d) {
+			if _, done := removed.set[smplx]; done || !pred(smplx) {
+				continue
+			}
+
+			removed.Add(smplx)
+			if cf := smplx.AllCofaces(); cf != nil {
+				removed.Add(cf.Slice()...)
+			}
+		}
+	}
+
+	c.remove(removed)
+
+	return removed
+}
+
+// remove takes the simplices in set, which must be closed under taking cofaces, out of c.
+func (c *Complex) remove(set *SimplicialSet) {
+	if set.Card() == 0 {
+		return
+	}
+
+	dims := map[Dim]struct{}{}
+
+	for smplx := range set.set {
+		dims[smplx.Dim()] = struct{}{}
+
+		group := c.chainGroups[smplx.Dim()]
+		delete(group.simplices, smplx.index)
+
+		if c.tree != nil {
+			c.tree.Remove(smplx.base...)
+			continue
+		}
+
+		delete(c.index, smplx.key())
+		for _, facet := range smplx.facets {
+			for idx, cofacet := range facet.cofacets {
+				if cofacet == smplx {
+					facet.cofacets = append(facet.cofacets[:idx], facet.cofacets[idx+1:]...)
+					break
+				}
+			}
+		}
+	}
+
+	// Removed simplices keep their vertices but nothing else ties them to c
+	for smplx := range set.set {
+		smplx.complex = nil
+		smplx.facets = nil
+		smplx.cofacets = nil
+	}
+
+	for d := range dims {
+		if c.tree != nil {
+			c.tree.reindex(d)
+			c.chainGroups[d].reindexMaterialized()
+			continue
+		}
+
+		c.chainGroups[d].reindex()
+	}
+
+	// The dimension of c drops if its top dimensional simplices are gone
+	if c.tree != nil {
+		for d := range c.chainGroups {
+			if d > c.tree.Dim() {
+				delete(c.chainGroups, d)
+			}
+		}
+		c.dim = c.tree.Dim()
+	}
+	for c.dim > 0 && c.chainGroups[c.dim] != nil && c.chainGroups[c.dim].Rank() == 0 {
+		delete(c.chainGroups, c.dim)
+		c.dim--
+	}
+
+	c.resetGroupCaches()
+	c.resetCache()
+}
+
+// reindex rebuilds the basis of cg after simplices have been taken out of it.
+// Simplices of positive dimension are given consecutive indices, preserving their order.
+func (cg *ChainGroup) reindex() {
+	remaining := Base{}
+	for idx := range cg.simplices {
+		remaining = append(remaining, idx)
+	}
+	cg.idxs = remaining
+	cg.idxsSorted = false
+	cg.sortIdxs()
+
+	cg.basespace = map[Index]struct{}{}
+	for _, smplx := range cg.simplices {
+		for _, v := range smplx.base {
+			cg.basespace[v] = struct{}{}
+		}
+	}
+
+	if cg.dim == 0 {
+		return
+	}
+
+	simplices := map[Index]*Simplex{}
+	for idx, old := range cg.idxs {
+		smplx := cg.simplices[old]
+		smplx.index = Index(idx)
+		simplices[smplx.index] = smplx
+		cg.idxs[idx] = smplx.index
+	}
+
+	cg.simplices = simplices
+	cg.head = Index(len(cg.idxs))
+}
+
+// reindexMaterialized files the simplices created from the SimplexTree backing the Complex of cg under their new indices.
+// The basis itself is read from the tree again by sync.
+func (cg *ChainGroup) reindexMaterialized() {
+	simplices := make(map[Index]*Simplex, len(cg.simplices))
+	for _, smplx := range cg.simplices {
+		simplices[smplx.index] = smplx
+	}
+
+	cg.simplices = simplices
+	cg.synced = false
+}
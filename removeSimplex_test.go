@@ -0,0 +1,112 @@
+package comptop
+
+import "testing"
+
+func TestComplex_RemoveSimplex(t *testing.T) {
+	for _, backend := range []string{"default", "simplex tree"} {
+		t.Run(backend, func(tt *testing.T) {
+			c := &Complex{}
+			if backend == "simplex tree" {
+				c = NewComplexFromSimplexTree(NewSimplexTree())
+			}
+			c.NewSimplices(Base{0, 1, 2}, Base{1, 2, 3})
+
+			// Compute some cached results before removing anything
+			c.BettiNumbers()
+			c.PrincipleSimplices()
+
+			removed := c.RemoveSimplex(c.GetSimplex(1, 2))
+			if count := removed.Card(); count != 3 {
+				tt.Fatalf("expected to remove 3 simplices, removed %d", count)
+			}
+
+			if c.GetSimplex(1, 2) != nil || c.GetSimplex(0, 1, 2) != nil {
+				tt.Error("removed simplices are still in the complex")
+			}
+
+			if count := c.ChainGroup(1).Rank(); count != 4 {
+				tt.Errorf("expected 4 edges, got %d", count)
+			}
+
+			if count := c.PrincipleSimplices().Card(); count != 4 {
+				tt.Errorf("expected 4 principle simplices, got %d", count)
+			}
+
+			// What's left is a square
+			expectedBN := []int{1, 1}
+			bn := c.BettiNumbers()
+			if len(expectedBN) != len(bn) {
+				tt.Fatalf("invalid number of Betti numbers: %v", bn)
+			}
+			for idx, ebn := range expectedBN {
+				if bn[idx] != ebn {
+					tt.Fatalf("Betti number %d is wrong; expected %d received %d", idx, ebn, bn[idx])
+				}
+			}
+
+			if cf := c.GetSimplex(1).AllCofaces(); cf.Card() != 2 {
+				tt.Errorf("expected [1] to have 2 cofaces, got %d", cf.Card())
+			}
+
+			c.RemoveVertex(3)
+			if x := c.EulerChar(); x != 1 {
+				tt.Errorf("expected Euler char of a path to be 1, got %d", x)
+			}
+
+			removed = c.RemoveSimplices(func(s *Simplex) bool {
+				return s.Dim() == 1
+			})
+			if count := removed.Card(); count != 2 {
+				tt.Errorf("expected to remove 2 edges, removed %d", count)
+			}
+
+			bn = c.BettiNumbers()
+			if len(bn) != 1 || bn[0] != 3 {
+				tt.Errorf("expected 3 isolated vertices, got Betti numbers %v", bn)
+			}
+		})
+	}
+}
+
+func TestComplex_RemoveSimplexTwice(t *testing.T) {
+	for _, backend := range []string{"default", "simplex tree"} {
+		t.Run(backend, func(tt *testing.T) {
+			c := &Complex{}
+			other := &Complex{}
+			if backend == "simplex tree" {
+				c = NewComplexFromSimplexTree(NewSimplexTree())
+			}
+			c.NewSimplices(Base{0, 1, 2}, Base{2, 3}, Base{3, 4}, Base{4, 0})
+			other.NewSimplices(Base{0, 1})
+
+			edge := c.GetSimplex(0, 1)
+			if removed := c.RemoveSimplex(edge); removed.Card() != 2 {
+				tt.Fatalf("expected to remove 2 simplices, removed %v", removed)
+			}
+			if removed := c.RemoveSimplex(edge); removed.Card() != 0 {
+				tt.Errorf("expected removing %v again to do nothing, removed %v", edge, removed)
+			}
+			if removed := c.RemoveSimplex(other.GetSimplex(0, 1)); removed.Card() != 0 {
+				tt.Errorf("expected a simplex of another complex not to be removed, removed %v", removed)
+			}
+
+			if count := c.ChainGroup(1).Rank(); count != 5 {
+				tt.Errorf("expected 5 edges, got %d", count)
+			}
+			seen := map[Index]bool{}
+			for _, e := range c.GetdSimplices(1) {
+				if seen[e.Index()] {
+					tt.Errorf("expected distinct edge indices, %v is repeated", e.Index())
+				}
+				seen[e.Index()] = true
+			}
+
+			// The cycle 0-2-3-4-0 is left
+			expectedBN := []int{1, 1}
+			bn := c.BettiNumbers()
+			if len(bn) != len(expectedBN) || bn[0] != expectedBN[0] || bn[1] != expectedBN[1] {
+				tt.Errorf("expected Betti numbers %v, got %v", expectedBN, bn)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package comptop
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// RipsComplex returns the Vietoris-Rips complex of the points in Euclidean space at scale r.
+// The i^th point becomes the 0-simplex with Index i and a set of points spans a Simplex if each pair of them is at most r apart.
+// Simplices of dimension larger than maxDim are not constructed.
+// The Data field of every Simplex holds its diameter (the largest distance between any two of its vertices) as a float64.
+//
+// More info: https://en.wikipedia.org/wiki/Vietoris%E2%80%93Rips_complex
+func RipsComplex(points [][]float64, r float64, maxDim Dim) *Complex {
+	if len(points) == 0 {
+		return &Complex{}
+	}
+
+	return RipsComplexFromDistances(euclideanDistances(points), r, maxDim)
+}
+
+// RipsComplexFromDistances returns the Vietoris-Rips complex at scale r of the metric space whose pairwise distances are given by d.
+// The i^th row of d becomes the 0-simplex with Index i and a set of points spans a Simplex if each pair of them is at most r apart.
+// Simplices of dimension larger than maxDim are not constructed.
+// The Data field of every Simplex holds its diameter (the largest distance between any two of its vertices) as a float64.
+//
+// More info: https://en.wikipedia.org/wiki/Vietoris%E2%80%93Rips_complex
+func RipsComplexFromDistances(d mat.Symmetric, r float64, maxDim Dim) *Complex {
+	c := &Complex{}
+	n := d.Symmetric()
+
+	// Build the neighborhood graph, only keeping track of neighbors with a larger index
+	// so that each clique is found exactly once.
+	neighbors := make([]Base, n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if d.At(i, j) <= r {
+				neighbors[i] = append(neighbors[i], Index(j))
+			}
+		}
+	}
+
+	cliques := []Base{}
+	for i := 0; i < n; i++ {
+		cliques = append(cliques, Base{Index(i)})
+	}
+
+	// Expand each clique by the common upper neighbors of its vertices,
+	// one dimension at a time.
+	frontier := cliques
+	for dim := Dim(1); dim <= maxDim && len(frontier) > 0; dim++ {
+		next := []Base{}
+		for _, clique := range frontier {
+			last := clique[len(clique)-1]
+			for _, v := range neighbors[last] {
+				if !isNeighborOfAll(neighbors, clique, v) {
+					continue
+				}
+
+				b := make(Base, len(clique)+1)
+				copy(b, clique)
+				b[len(clique)] = v
+				next = append(next, b)
+			}
+		}
+
+		cliques = append(cliques, next...)
+		frontier = next
+	}
+
+	c.NewSimplices(cliques...)
+
+	for dim := Dim(0); dim <= c.dim; dim++ {
+		for _, smplx := range c.GetdSimplices(dim) {
+			smplx.Data = diameter(d, smplx.base)
+		}
+	}
+
+	return c
+}
+
+// isNeighborOfAll returns true if v is an upper neighbor of every vertex in clique.
+func isNeighborOfAll(neighbors []Base, clique Base, v Index) bool {
+	for _, u := range clique {
+		nbrs := neighbors[u]
+		idx := sort.Search(len(nbrs), func(j int) bool {
+			return nbrs[j] >= v
+		})
+		if idx == len(nbrs) || nbrs[idx] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// diameter returns the largest distance in d between any two vertices in b.
+func diameter(d mat.Symmetric, b Base) float64 {
+	var diam float64
+	for i := range b {
+		for j := i + 1; j < len(b); j++ {
+			if x := d.At(int(b[i]), int(b[j])); x > diam {
+				diam = x
+			}
+		}
+	}
+
+	return diam
+}
+
+// euclideanDistances returns the matrix of pairwise Euclidean distances between points.
+func euclideanDistances(points [][]float64) *mat.SymDense {
+	n := len(points)
+	d := mat.NewSymDense(n, nil)
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			var sum float64
+			for k := range points[i] {
+				x := points[i][k] - points[j][k]
+				sum += x * x
+			}
+			d.SetSym(i, j, math.Sqrt(sum))
+		}
+	}
+
+	return d
+}
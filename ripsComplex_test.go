@@ -0,0 +1,54 @@
+package comptop
+
+import "testing"
+
+func TestRipsComplex(t *testing.T) {
+	// The corners of the unit square
+	points := [][]float64{
+		{0, 0}, {1, 0}, {1, 1}, {0, 1},
+	}
+
+	// Only the sides of the square are short enough to be included, leaving a hole.
+	c := RipsComplex(points, 1.1, 2)
+
+	expectedBN := []int{1, 1}
+	bn := c.BettiNumbers()
+
+	if len(expectedBN) != len(bn) {
+		t.Fatalf("invalid number of Betti numbers: %v", bn)
+	}
+
+	for idx, ebn := range expectedBN {
+		if bn[idx] != ebn {
+			t.Fatalf("Betti number %d is wrong; expected %d received %d", idx, ebn, bn[idx])
+		}
+	}
+
+	if diam := c.GetSimplex(0, 1).Data.(float64); diam != 1 {
+		t.Errorf("expected edge [0 1] to have diameter 1, got %f", diam)
+	}
+
+	// The diagonals are now included, filling in the hole.
+	c = RipsComplex(points, 1.5, 3)
+
+	expectedBN = []int{1, 0, 0, 0}
+	bn = c.BettiNumbers()
+
+	if len(expectedBN) != len(bn) {
+		t.Fatalf("invalid number of Betti numbers: %v", bn)
+	}
+
+	for idx, ebn := range expectedBN {
+		if bn[idx] != ebn {
+			t.Fatalf("Betti number %d is wrong; expected %d received %d", idx, ebn, bn[idx])
+		}
+	}
+
+	if count := len(c.GetdSimplices(2)); count != 4 {
+		t.Errorf("expected 4 triangles, got %d", count)
+	}
+
+	if smplx := c.GetSimplex(0, 1, 2, 3); smplx == nil || smplx.Data.(float64) <= 1.4 {
+		t.Errorf("expected tetrahedron with diameter sqrt(2), got %v", smplx)
+	}
+}
@@ -0,0 +1,254 @@
+package comptop
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// EdgeWeight returns the length of an edge (a 1-dimensional Simplex); lengths must not be negative.
+type EdgeWeight func(*Simplex) float64
+
+// dataWeight uses the Data of an edge as its length when it is a float64 (as it is for the edges of a Rips complex) and 1 otherwise.
+func dataWeight(s *Simplex) float64 {
+	if w, ok := s.Data.(float64); ok {
+		return w
+	}
+
+	return 1
+}
+
+// ShortestBasis returns a basis for the first homology group whose total length is as small as possible, where the length of a cycle is the sum of the lengths of its edges.
+// If w is nil, the length of an edge is its Data when that is a float64, and 1 otherwise.
+//
+// Candidate cycles are made from a shortest path tree rooted at each vertex: every edge outside the tree closes a loop through the root.
+// A shortest basis can always be found among these candidates, so adding candidates from shortest to longest whenever their classes are independent
+// of the ones added before them yields a shortest basis.
+// Classes are compared through an annotation of the edges, read off a basis of the first cohomology group:
+// the annotation of a cycle is zero exactly when the cycle is a boundary, and the annotation of a candidate is found from the tree in O(rank) time.
+// ShortestBasis returns nil unless hg is 1-dimensional and computed over Z_2.
+//
+// More info: 'Annotating Simplices with a Homology Basis and Its Applications' by Busaryev, Cabello, Chen, Dey & Wang.
+func (hg *HomologyGroup) ShortestBasis(w EdgeWeight) []*Chain {
+	cg := hg.chainGroup
+	if cg.dim != 1 || !isBinary(cg.Field()) {
+		return nil
+	}
+
+	if w == nil {
+		w = dataWeight
+	}
+
+	// The i^th bit of the annotation of an edge is the value of the i^th cocycle of a cohomology basis on it
+	cocycles := cg.CohomologyGroup().Basis()
+	rank := len(cocycles)
+	basis := []*Chain{}
+	if rank == 0 {
+		return basis
+	}
+
+	reducer := newGF2Reducer(rank)
+
+	cg.sortIdxs()
+	edges := make([]*Simplex, len(cg.idxs))
+	rows := make(map[*Simplex]int, len(cg.idxs))
+	annotations := make([][]uint64, len(cg.idxs))
+	for row, idx := range cg.idxs {
+		edges[row] = cg.Simplex(idx)
+		rows[edges[row]] = row
+		annotations[row] = reducer.vector()
+	}
+	for i, cocycle := range cocycles {
+		for row, x := range cocycle.column() {
+			if x.Sign() != 0 {
+				annotations[row][i/64] ^= 1 << uint(i%64)
+			}
+		}
+	}
+
+	// Adjacency lists and edge lengths
+	weights := make(map[*Simplex]float64, len(edges))
+	adjacent := map[Index][]*Simplex{}
+	for _, edge := range edges {
+		weights[edge] = w(edge)
+		for _, v := range edge.base {
+			adjacent[v] = append(adjacent[v], edge)
+		}
+	}
+
+	// Candidates which are boundaries are left out; the paths through the root are only recovered for the candidates in the basis
+	type candidate struct {
+		root       Index
+		edge       int
+		length     float64
+		annotation []uint64
+	}
+
+	candidates := []candidate{}
+	for _, root := range cg.complex.GetdSimplices(0) {
+		tree := newShortestPathTree(root.base[0], adjacent, weights, nil)
+
+		// The annotation of the path from the root to each vertex, in the order the vertices were settled
+		paths := map[Index][]uint64{root.base[0]: reducer.vector()}
+		for _, v := range tree.order[1:] {
+			edge := tree.parent[v]
+			path := append([]uint64(nil), paths[edge.otherEnd(v)]...)
+			for word, bits := range annotations[rows[edge]] {
+				path[word] ^= bits
+			}
+			paths[v] = path
+		}
+
+		for row, edge := range edges {
+			a, b := edge.base[0], edge.base[1]
+			da, okA := tree.dist[a]
+			db, okB := tree.dist[b]
+			if !okA || !okB || tree.parent[a] == edge || tree.parent[b] == edge {
+				continue
+			}
+
+			annotation := reducer.vector()
+			zero := true
+			for word := range annotation {
+				annotation[word] = paths[a][word] ^ paths[b][word] ^ annotations[row][word]
+				zero = zero && annotation[word] == 0
+			}
+			if zero {
+				continue
+			}
+
+			candidates = append(candidates, candidate{
+				root:       root.base[0],
+				edge:       row,
+				length:     da + db + weights[edge],
+				annotation: annotation,
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].length < candidates[j].length
+	})
+
+	for _, cand := range candidates {
+		if !reducer.add(cand.annotation) {
+			continue
+		}
+
+		// The loop root -> a, a -> b, b -> root; edges shared by both paths cancel out
+		edge := edges[cand.edge]
+		tree := newShortestPathTree(cand.root, adjacent, weights, edge.base)
+
+		count := map[*Simplex]int{edge: 1}
+		for _, end := range edge.base {
+			for e := tree.parent[end]; e != nil; e = tree.parent[end] {
+				count[e]++
+				end = e.otherEnd(end)
+			}
+		}
+
+		simplices := []*Simplex{}
+		for e, n := range count {
+			if n%2 == 1 {
+				simplices = append(simplices, e)
+			}
+		}
+
+		basis = append(basis, cg.NewChainFromSimplices(simplices...))
+		if len(basis) == rank {
+			break
+		}
+	}
+
+	return basis
+}
+
+// otherEnd returns the vertex of the edge s which is not v.
+func (s *Simplex) otherEnd(v Index) Index {
+	if s.base[0] == v {
+		return s.base[1]
+	}
+
+	return s.base[0]
+}
+
+// shortestPathTree holds the distances from a root vertex and the edge leading back towards the root from each reachable vertex.
+type shortestPathTree struct {
+	dist   map[Index]float64
+	parent map[Index]*Simplex
+
+	// order lists the settled vertices, starting with the root, in the order they were settled
+	order Base
+}
+
+// newShortestPathTree runs Dijkstra's algorithm from root.
+// If targets is not nil, the search stops once every target is settled; the paths to the targets are then the same as in the full tree,
+// since a settled vertex and the vertices on its path to the root never change parent again.
+//
+// More info: https://en.wikipedia.org/wiki/Dijkstra%27s_algorithm
+func newShortestPathTree(root Index, adjacent map[Index][]*Simplex, weights map[*Simplex]float64, targets Base) *shortestPathTree {
+	tree := &shortestPathTree{
+		dist:   map[Index]float64{root: 0},
+		parent: map[Index]*Simplex{root: nil},
+	}
+
+	done := map[Index]struct{}{}
+	pq := &vertexQueue{{vertex: root}}
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(vertexDist)
+		if _, finished := done[item.vertex]; finished {
+			continue
+		}
+		done[item.vertex] = struct{}{}
+		tree.order = append(tree.order, item.vertex)
+
+		if targets != nil && settled(done, targets) {
+			break
+		}
+
+		for _, edge := range adjacent[item.vertex] {
+			next := edge.otherEnd(item.vertex)
+			d := item.dist + weights[edge]
+			if old, seen := tree.dist[next]; seen && old <= d {
+				continue
+			}
+
+			tree.dist[next] = d
+			tree.parent[next] = edge
+			heap.Push(pq, vertexDist{vertex: next, dist: d})
+		}
+	}
+
+	return tree
+}
+
+// settled returns true if every target is done.
+func settled(done map[Index]struct{}, targets Base) bool {
+	for _, v := range targets {
+		if _, ok := done[v]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+type vertexDist struct {
+	vertex Index
+	dist   float64
+}
+
+// vertexQueue is a min-heap of vertices by distance.
+type vertexQueue []vertexDist
+
+func (q vertexQueue) Len() int            { return len(q) }
+func (q vertexQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q vertexQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *vertexQueue) Push(x interface{}) { *q = append(*q, x.(vertexDist)) }
+func (q *vertexQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+
+	return item
+}
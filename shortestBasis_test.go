@@ -0,0 +1,152 @@
+package comptop
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHomologyGroup_ShortestBasis(t *testing.T) {
+	t.Run("figure eight", func(tt *testing.T) {
+		// A triangle and a square sharing vertex 0, with a chord [2 4] closing two more loops of 4 edges
+		c := &Complex{}
+		c.NewSimplices([]Base{
+			{0, 1}, {1, 2}, {0, 2},
+			{0, 3}, {3, 4}, {4, 5}, {0, 5},
+			{2, 4},
+		}...)
+
+		basis := c.ChainGroup(1).HomologyGroup().ShortestBasis(nil)
+		if len(basis) != 3 {
+			tt.Fatalf("expected 3 cycles, got %d", len(basis))
+		}
+
+		expectedLengths := []int{3, 4, 4}
+		for idx, chain := range basis {
+			if chain.Len() != expectedLengths[idx] {
+				tt.Errorf("expected cycle %d to have %d edges, got %v", idx, expectedLengths[idx], chain)
+			}
+			if chain.Boundary().Len() != 0 {
+				tt.Errorf("%v is not a cycle", chain)
+			}
+		}
+	})
+
+	t.Run("weights", func(tt *testing.T) {
+		// Two triangles sharing the edge [0 1]; making it long puts the outer square first
+		c := &Complex{}
+		c.NewSimplices([]Base{{0, 1}, {0, 2}, {1, 2}, {0, 3}, {1, 3}}...)
+
+		long := c.GetSimplex(0, 1)
+		long.Data = 10.0
+
+		basis := c.ChainGroup(1).HomologyGroup().ShortestBasis(nil)
+		if len(basis) != 2 {
+			tt.Fatalf("expected 2 cycles, got %d", len(basis))
+		}
+
+		var total float64
+		for _, chain := range basis {
+			for _, edge := range chain.Simplices() {
+				total += dataWeight(edge)
+			}
+		}
+		if total != 4+12 {
+			tt.Errorf("expected a total length of 16, got %v from %v", total, basis)
+		}
+
+		unit := func(*Simplex) float64 { return 1 }
+		for _, chain := range c.ChainGroup(1).HomologyGroup().ShortestBasis(unit) {
+			if chain.Len() != 3 {
+				tt.Errorf("expected triangles with unit weights, got %v", chain)
+			}
+		}
+	})
+
+	t.Run("torus", func(tt *testing.T) {
+		c := &Complex{}
+		c.NewSimplices([]Base{
+			{0, 1, 3}, {1, 3, 4}, {1, 2, 4}, {2, 4, 5}, {0, 2, 5}, {0, 3, 5},
+			{3, 4, 6}, {4, 6, 7}, {4, 5, 7}, {5, 7, 8}, {3, 5, 8}, {3, 6, 8},
+			{0, 6, 7}, {0, 1, 7}, {1, 7, 8}, {1, 2, 8}, {2, 6, 8}, {0, 2, 6},
+		}...)
+
+		hg := c.ChainGroup(1).HomologyGroup()
+		basis := hg.ShortestBasis(nil)
+		if len(basis) != 2 {
+			tt.Fatalf("expected 2 cycles, got %d", len(basis))
+		}
+
+		// Each generator of the torus needs at least 3 edges
+		for _, chain := range basis {
+			if chain.Len() != 3 {
+				tt.Errorf("expected a loop of 3 edges, got %v", chain)
+			}
+		}
+
+		// The cycles must be independent in homology
+		a, b := hg.Coordinates(basis[0]), hg.Coordinates(basis[1])
+		if fieldRank(Z2, a, b) != 2 {
+			tt.Errorf("expected independent classes, got coordinates %v and %v", a, b)
+		}
+	})
+
+	t.Run("grid torus", func(tt *testing.T) {
+		c := gridTorus(8)
+
+		hg := c.ChainGroup(1).HomologyGroup()
+		basis := hg.ShortestBasis(nil)
+		if len(basis) != 2 {
+			tt.Fatalf("expected 2 cycles, got %d", len(basis))
+		}
+
+		// The shortest loops around the torus run along a row or a column of the grid
+		for _, chain := range basis {
+			if chain.Len() != 8 || !chain.IsCycle() {
+				tt.Errorf("expected a loop of 8 edges, got %v", chain)
+			}
+		}
+
+		a, b := hg.Coordinates(basis[0]), hg.Coordinates(basis[1])
+		if fieldRank(Z2, a, b) != 2 {
+			tt.Errorf("expected independent classes, got coordinates %v and %v", a, b)
+		}
+	})
+}
+
+// gridTorus returns the triangulation of an n by n grid whose opposite sides are glued together.
+func gridTorus(n int) *Complex {
+	vertex := func(i, j int) Index {
+		return Index((i%n)*n + j%n)
+	}
+
+	bases := []Base{}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			bases = append(bases,
+				Base{vertex(i, j), vertex(i+1, j), vertex(i+1, j+1)},
+				Base{vertex(i, j), vertex(i, j+1), vertex(i+1, j+1)},
+			)
+		}
+	}
+
+	c := &Complex{}
+	c.NewSimplices(bases...)
+
+	return c
+}
+
+func BenchmarkHomologyGroup_ShortestBasis(b *testing.B) {
+	for _, n := range []int{10, 20, 30} {
+		b.Run(fmt.Sprintf("torus %dx%d", n, n), func(bb *testing.B) {
+			for i := 0; i < bb.N; i++ {
+				bb.StopTimer()
+				c := gridTorus(n)
+				bb.StartTimer()
+
+				if basis := c.ChainGroup(1).HomologyGroup().ShortestBasis(nil); len(basis) != 2 {
+					bb.Fatalf("expected 2 cycles, got %d", len(basis))
+				}
+			}
+		})
+	}
+}
@@ -40,10 +40,25 @@ func (s *simplex) equals(f *simplex) bool {
 	return true
 }
 
+// key returns a string which uniquely identifies the vertex set of s.
+func (s *simplex) key() string {
+	s.sort()
+
+	b := make([]byte, 0, 8*len(s.base))
+	for _, v := range s.base {
+		for shift := uint(0); shift < 64; shift += 8 {
+			b = append(b, byte(uint64(v)>>shift))
+		}
+	}
+
+	return string(b)
+}
+
 func (s *simplex) dim() Dim {
 	return Dim(len(s.base)) - 1
 }
 
+// d returns the codimension 1 faces of s; the j^th face omits the j^th vertex and appears with sign (-1)^j in the oriented boundary of s.
 func (s *simplex) d() []*simplex {
 	sortsimplices(s)
 
@@ -66,6 +81,25 @@ func (s *simplex) d() []*simplex {
 	return boundary
 }
 
+// faceSign returns the sign with which the codimension 1 face f appears in the oriented boundary of s.
+// The sign is (-1)^j, where j is the position in the sorted base of s of the vertex missing from f.
+func (s *simplex) faceSign(f *simplex) int {
+	sortsimplices(s, f)
+
+	j := 0
+	for ; j < len(f.base); j++ {
+		if s.base[j] != f.base[j] {
+			break
+		}
+	}
+
+	if j%2 == 0 {
+		return 1
+	}
+
+	return -1
+}
+
 // Simplex is a p-dimensional polytope which is the convex hull of its p+1 0-dimensional simplices (points/vertices).
 // Every Simplex should be part of a Complex; every Simplex in a Complex is considered to live in the same topological space.
 // Simplex is uniquely identified in its Complex by its dimension ((*Simplex).Dim) and Index ((*Simplex).Index).
@@ -80,6 +114,10 @@ type Simplex struct {
 
 	faces map[Dim]*SimplicialSet
 
+	// facets are the codimension 1 faces of the Simplex; cofacets are the simplices it is a codimension 1 face of
+	facets   []*Simplex
+	cofacets []*Simplex
+
 	Data interface{}
 }
 
@@ -140,10 +178,24 @@ func (s *Simplex) Base() Base {
 
 // HasFace returns true if s has f as a face.
 func (s *Simplex) HasFace(f *Simplex) bool {
-	if s.Intersection(f).Equals(f) {
-		return true
+	if s == nil || f == nil || s.complex != f.complex {
+		return false
+	}
+
+	sortSimplices(s, f)
+
+	// f is a face of s if its base is a subset of the base of s
+	idx := 0
+	for _, v := range f.base {
+		for idx < len(s.base) && s.base[idx] < v {
+			idx++
+		}
+		if idx == len(s.base) || s.base[idx] != v {
+			return false
+		}
 	}
-	return false
+
+	return true
 }
 
 // Intersection returns the intersection of simplices s and g.
@@ -195,17 +247,30 @@ func (s *Simplex) Faces(d Dim) *SimplicialSet {
 		return faces
 	}
 
-	faces := []*Simplex{}
-	complex := s.complex
-	group := complex.chainGroups[d]
+	if tree := s.complex.tree; tree != nil {
+		faces := map[*Simplex]struct{}{}
+		for _, b := range subsets(s.Base(), int(d)+1) {
+			faces[s.complex.GetSimplex(b...)] = struct{}{}
+		}
+
+		s.faces[d] = &SimplicialSet{set: faces}
 
-	for _, smplx := range group.simplices {
-		if s.HasFace(smplx) {
-			faces = append(faces, smplx)
+		return s.faces[d]
+	}
+
+	// Walk down from s one dimension at a time
+	level := map[*Simplex]struct{}{s: {}}
+	for k := s.Dim(); k > d; k-- {
+		next := map[*Simplex]struct{}{}
+		for smplx := range level {
+			for _, facet := range smplx.facets {
+				next[facet] = struct{}{}
+			}
 		}
+		level = next
 	}
 
-	s.faces[d] = NewSimplicialSet(faces...)
+	s.faces[d] = &SimplicialSet{set: level}
 
 	return s.faces[d]
 }
@@ -216,17 +281,30 @@ func (s *Simplex) Cofaces(d Dim) *SimplicialSet {
 		return nil
 	}
 
-	cf := map[*Simplex]struct{}{}
+	if tree := s.complex.tree; tree != nil {
+		cf := map[*Simplex]struct{}{}
+		for _, n := range tree.cofaces(s.Base()) {
+			if n.depth == int(d)+1 {
+				cf[s.complex.materialize(n)] = struct{}{}
+			}
+		}
 
-	simplices := s.complex.chaingroup(d).Simplices()
+		return &SimplicialSet{set: cf}
+	}
 
-	for _, smplx := range simplices {
-		if smplx.HasFace(s) {
-			cf[smplx] = struct{}{}
+	// Walk up from s one dimension at a time
+	level := map[*Simplex]struct{}{s: {}}
+	for k := s.Dim(); k < d; k++ {
+		next := map[*Simplex]struct{}{}
+		for smplx := range level {
+			for _, cofacet := range smplx.cofacets {
+				next[cofacet] = struct{}{}
+			}
 		}
+		level = next
 	}
 
-	return &SimplicialSet{set: cf}
+	return &SimplicialSet{set: level}
 }
 
 // AllCofaces returns the set of simplices of any dimension that have s as a face.
@@ -236,19 +314,68 @@ func (s *Simplex) AllCofaces() *SimplicialSet {
 	}
 
 	cf := map[*Simplex]struct{}{}
-	for d := s.Dim() + 1; d <= s.complex.dim; d++ {
-		simplices := s.complex.chaingroup(d).Simplices()
 
-		for _, smplx := range simplices {
-			if smplx.HasFace(s) {
-				cf[smplx] = struct{}{}
+	if tree := s.complex.tree; tree != nil {
+		for _, n := range tree.cofaces(s.Base()) {
+			cf[s.complex.materialize(n)] = struct{}{}
+		}
+
+		return &SimplicialSet{set: cf}
+	}
+
+	stack := []*Simplex{s}
+	for len(stack) > 0 {
+		n := len(stack) - 1
+		smplx := stack[n]
+		stack = stack[:n]
+
+		for _, cofacet := range smplx.cofacets {
+			if _, seen := cf[cofacet]; seen {
+				continue
 			}
+			cf[cofacet] = struct{}{}
+			stack = append(stack, cofacet)
 		}
 	}
 
 	return &SimplicialSet{set: cf}
 }
 
+// subsets returns the subsets of b with k elements, in lexicographic order.
+func subsets(b Base, k int) []Base {
+	sets := []Base{}
+	if k > len(b) || k <= 0 {
+		return sets
+	}
+
+	idxs := make([]int, k)
+	for i := range idxs {
+		idxs[i] = i
+	}
+
+	for {
+		set := make(Base, k)
+		for i, idx := range idxs {
+			set[i] = b[idx]
+		}
+		sets = append(sets, set)
+
+		// Advance to the next combination of positions
+		i := k - 1
+		for i >= 0 && idxs[i] == len(b)-k+i {
+			i--
+		}
+		if i < 0 {
+			return sets
+		}
+
+		idxs[i]++
+		for j := i + 1; j < k; j++ {
+			idxs[j] = idxs[j-1] + 1
+		}
+	}
+}
+
 func sortsimplices(simplices ...*simplex) {
 	for _, s := range simplices {
 		s.sort()
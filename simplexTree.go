@@ -0,0 +1,440 @@
+package comptop
+
+import (
+	"sort"
+)
+
+// SimplexTree is a compact representation of an abstract simplicial complex.
+// Every simplex is stored as the path of its sorted vertices from the root of a trie, so a simplex costs a single node.
+// Nodes sharing a vertex at the same depth are linked together so that cofaces can be found without visiting the whole tree.
+//
+// A SimplexTree can serve as the storage backend for a Complex (see NewComplexFromSimplexTree).
+//
+// More info: 'The Simplex Tree: An Efficient Data Structure for General Simplicial Complexes' by Boissonnat & Maria.
+type SimplexTree struct {
+	root *stNode
+
+	// lists holds the nodes with a given vertex, by depth
+	lists map[Index][][]*stNode
+
+	counts []int
+
+	// indexed maps the index of each simplex to its node, by dimension;
+	// vertices are indexed by their label and other simplices by order of insertion, as in a ChainGroup
+	indexed []map[Index]*stNode
+	heads   []Index
+}
+
+type stNode struct {
+	label    Index
+	index    Index
+	parent   *stNode
+	children []*stNode
+	depth    int
+
+	// simplex is the Simplex materialized from this node when the tree backs a Complex
+	simplex *Simplex
+}
+
+// NewSimplexTree returns an empty SimplexTree.
+func NewSimplexTree() *SimplexTree {
+	return &SimplexTree{
+		root:  &stNode{},
+		lists: map[Index][][]*stNode{},
+	}
+}
+
+// child returns the child of n with the given label; returns nil if there isn't one.
+func (n *stNode) child(label Index) *stNode {
+	idx := sort.Search(len(n.children), func(j int) bool {
+		return n.children[j].label >= label
+	})
+	if idx < len(n.children) && n.children[idx].label == label {
+		return n.children[idx]
+	}
+
+	return nil
+}
+
+func (n *stNode) base() Base {
+	b := make(Base, n.depth)
+	for node := n; node.parent != nil; node = node.parent {
+		b[node.depth-1] = node.label
+	}
+
+	return b
+}
+
+// normalizeBase returns a sorted copy of base without repeated vertices.
+func normalizeBase(base Base) Base {
+	b := append(Base(nil), base...)
+	sort.Sort(b)
+
+	n := 0
+	for idx, v := range b {
+		if idx > 0 && v == b[n-1] {
+			continue
+		}
+		b[n] = v
+		n++
+	}
+
+	return b[:n]
+}
+
+// Insert adds the simplex with the given vertices, along with all of its faces, to the tree.
+// It returns the vertex sets of the simplices which weren't already in the tree.
+func (st *SimplexTree) Insert(base ...Index) []Base {
+	b := normalizeBase(base)
+	added := []*stNode{}
+
+	st.insert(st.root, b, &added)
+
+	bases := make([]Base, len(added))
+	for idx, node := range added {
+		bases[idx] = node.base()
+	}
+
+	return bases
+}
+
+func (st *SimplexTree) insert(n *stNode, vertices Base, added *[]*stNode) {
+	for idx, v := range vertices {
+		child := n.child(v)
+		if child == nil {
+			child = &stNode{
+				label:  v,
+				parent: n,
+				depth:  n.depth + 1,
+			}
+
+			pos := sort.Search(len(n.children), func(j int) bool {
+				return n.children[j].label >= v
+			})
+			n.children = append(n.children, nil)
+			copy(n.children[pos+1:], n.children[pos:])
+			n.children[pos] = child
+
+			lists := st.lists[v]
+			for len(lists) <= child.depth {
+				lists = append(lists, nil)
+			}
+			lists[child.depth] = append(lists[child.depth], child)
+			st.lists[v] = lists
+
+			for len(st.counts) < child.depth {
+				st.counts = append(st.counts, 0)
+			}
+			st.counts[child.depth-1]++
+			st.setIndex(child)
+
+			*added = append(*added, child)
+		}
+
+		st.insert(child, vertices[idx+1:], added)
+	}
+}
+
+// setIndex gives the new node n the next index of its dimension.
+func (st *SimplexTree) setIndex(n *stNode) {
+	d := n.depth - 1
+	for len(st.indexed) <= d {
+		st.indexed = append(st.indexed, map[Index]*stNode{})
+		st.heads = append(st.heads, 0)
+	}
+
+	n.index = n.label
+	if d > 0 {
+		n.index = st.heads[d]
+		st.heads[d]++
+	}
+
+	st.indexed[d][n.index] = n
+}
+
+// node returns the node of the simplex of dimension d with the given index; returns nil if there isn't one.
+func (st *SimplexTree) node(d Dim, idx Index) *stNode {
+	if int(d) >= len(st.indexed) {
+		return nil
+	}
+
+	return st.indexed[d][idx]
+}
+
+// indices returns the indices of the simplices of dimension d, in no particular order.
+func (st *SimplexTree) indices(d Dim) Base {
+	if int(d) >= len(st.indexed) {
+		return Base{}
+	}
+
+	idxs := make(Base, 0, len(st.indexed[d]))
+	for idx := range st.indexed[d] {
+		idxs = append(idxs, idx)
+	}
+
+	return idxs
+}
+
+// reindex gives the simplices of positive dimension d consecutive indices, preserving their order.
+// Simplices already materialized from the nodes are given their new index as well.
+func (st *SimplexTree) reindex(d Dim) {
+	if d == 0 || int(d) >= len(st.indexed) {
+		return
+	}
+
+	idxs := st.indices(d)
+	sort.Sort(idxs)
+
+	indexed := make(map[Index]*stNode, len(idxs))
+	for idx, old := range idxs {
+		n := st.indexed[d][old]
+		n.index = Index(idx)
+		if n.simplex != nil {
+			n.simplex.index = n.index
+		}
+		indexed[n.index] = n
+	}
+
+	st.indexed[d] = indexed
+	st.heads[d] = Index(len(idxs))
+}
+
+// find returns the node of the simplex with the given (sorted) vertices; returns nil if it isn't in the tree.
+func (st *SimplexTree) find(b Base) *stNode {
+	n := st.root
+	for _, v := range b {
+		if n = n.child(v); n == nil {
+			return nil
+		}
+	}
+
+	if n == st.root {
+		return nil
+	}
+
+	return n
+}
+
+// Remove removes the simplex with the given vertices, along with all of its cofaces, from the tree.
+// It returns the vertex sets of the removed simplices.
+func (st *SimplexTree) Remove(base ...Index) []Base {
+	b := normalizeBase(base)
+	n := st.find(b)
+	if n == nil {
+		return nil
+	}
+
+	nodes := append([]*stNode{n}, st.cofaces(b)...)
+	bases := make([]Base, len(nodes))
+	for idx, node := range nodes {
+		bases[idx] = node.base()
+	}
+
+	for _, node := range nodes {
+		parent := node.parent
+		for idx, child := range parent.children {
+			if child == node {
+				parent.children = append(parent.children[:idx], parent.children[idx+1:]...)
+				break
+			}
+		}
+
+		list := st.lists[node.label][node.depth]
+		for idx, other := range list {
+			if other == node {
+				st.lists[node.label][node.depth] = append(list[:idx], list[idx+1:]...)
+				break
+			}
+		}
+
+		st.counts[node.depth-1]--
+		delete(st.indexed[node.depth-1], node.index)
+	}
+
+	for len(st.counts) > 0 && st.counts[len(st.counts)-1] == 0 {
+		st.counts = st.counts[:len(st.counts)-1]
+	}
+
+	return bases
+}
+
+// Contains returns true if the simplex with the given vertices is in the tree.
+func (st *SimplexTree) Contains(base ...Index) bool {
+	return st.find(normalizeBase(base)) != nil
+}
+
+// Dim returns the dimension of the largest simplex in the tree; an empty tree has dimension 0.
+func (st *SimplexTree) Dim() Dim {
+	if len(st.counts) == 0 {
+		return 0
+	}
+
+	return Dim(len(st.counts)) - 1
+}
+
+// Count returns the number of simplices of dimension d in the tree.
+func (st *SimplexTree) Count(d Dim) int {
+	if int(d) >= len(st.counts) {
+		return 0
+	}
+
+	return st.counts[d]
+}
+
+// Len returns the number of simplices in the tree.
+func (st *SimplexTree) Len() int {
+	var n int
+	for _, c := range st.counts {
+		n += c
+	}
+
+	return n
+}
+
+// Simplices returns the vertex sets of the simplices of dimension d, in lexicographic order.
+func (st *SimplexTree) Simplices(d Dim) []Base {
+	bases := []Base{}
+	st.walk(st.root, int(d)+1, func(n *stNode) {
+		bases = append(bases, n.base())
+	})
+
+	return bases
+}
+
+// walk calls f on each node at the given depth below n, in lexicographic order.
+func (st *SimplexTree) walk(n *stNode, depth int, f func(*stNode)) {
+	if n.depth == depth {
+		f(n)
+		return
+	}
+
+	for _, child := range n.children {
+		st.walk(child, depth, f)
+	}
+}
+
+// cofaces returns the nodes of the simplices which have the simplex with vertices b as a proper face.
+func (st *SimplexTree) cofaces(b Base) []*stNode {
+	nodes := []*stNode{}
+	if len(b) == 0 {
+		return nodes
+	}
+
+	// Every coface contains the largest vertex of b at a depth no smaller than the length of b.
+	// Its ancestors on the path to that vertex must contain the rest of b; its descendants are cofaces as well.
+	last := b[len(b)-1]
+	lists := st.lists[last]
+	for depth := len(b); depth < len(lists); depth++ {
+		for _, n := range lists[depth] {
+			if !n.hasAncestors(b[:len(b)-1]) {
+				continue
+			}
+
+			if depth > len(b) {
+				nodes = append(nodes, n)
+			}
+			st.descendants(n, &nodes)
+		}
+	}
+
+	return nodes
+}
+
+// hasAncestors returns true if each vertex in the sorted base b labels a proper ancestor of n.
+func (n *stNode) hasAncestors(b Base) bool {
+	idx := len(b) - 1
+	for node := n.parent; node.parent != nil && idx >= 0; node = node.parent {
+		if node.label == b[idx] {
+			idx--
+		} else if node.label < b[idx] {
+			return false
+		}
+	}
+
+	return idx < 0
+}
+
+func (st *SimplexTree) descendants(n *stNode, nodes *[]*stNode) {
+	for _, child := range n.children {
+		*nodes = append(*nodes, child)
+		st.descendants(child, nodes)
+	}
+}
+
+// Cofaces returns the vertex sets of the simplices which have the simplex with the given vertices as a proper face.
+func (st *SimplexTree) Cofaces(base ...Index) []Base {
+	b := normalizeBase(base)
+	if st.find(b) == nil {
+		return nil
+	}
+
+	nodes := st.cofaces(b)
+	bases := make([]Base, len(nodes))
+	for idx, n := range nodes {
+		bases[idx] = n.base()
+	}
+
+	return bases
+}
+
+// Star returns the vertex sets of the simplices which have the simplex with the given vertices as a face, including the simplex itself.
+//
+// More info: https://en.wikipedia.org/wiki/Star_(simplicial_complex)
+func (st *SimplexTree) Star(base ...Index) []Base {
+	b := normalizeBase(base)
+	if st.find(b) == nil {
+		return nil
+	}
+
+	return append([]Base{b}, st.Cofaces(b...)...)
+}
+
+// Link returns the vertex sets of the simplices which are disjoint from the simplex with the given vertices,
+// but whose union with it is in the tree.
+//
+// More info: https://en.wikipedia.org/wiki/Link_(simplicial_complex)
+func (st *SimplexTree) Link(base ...Index) []Base {
+	b := normalizeBase(base)
+	if st.find(b) == nil {
+		return nil
+	}
+
+	link := []Base{}
+	for _, coface := range st.Cofaces(b...) {
+		tau := Base{}
+		idx := 0
+		for _, v := range coface {
+			if idx < len(b) && b[idx] == v {
+				idx++
+				continue
+			}
+			tau = append(tau, v)
+		}
+		link = append(link, tau)
+	}
+
+	return link
+}
+
+// MaximalSimplices returns the vertex sets of the simplices which aren't a proper face of any other simplex in the tree.
+func (st *SimplexTree) MaximalSimplices() []Base {
+	bases := []Base{}
+	for _, n := range st.maximal() {
+		bases = append(bases, n.base())
+	}
+
+	return bases
+}
+
+func (st *SimplexTree) maximal() []*stNode {
+	nodes := []*stNode{}
+	for d := 0; d < len(st.counts); d++ {
+		st.walk(st.root, d+1, func(n *stNode) {
+			if len(n.children) == 0 && len(st.cofaces(n.base())) == 0 {
+				nodes = append(nodes, n)
+			}
+		})
+	}
+
+	return nodes
+}
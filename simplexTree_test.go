@@ -0,0 +1,177 @@
+package comptop
+
+import "testing"
+
+func TestSimplexTree(t *testing.T) {
+	st := NewSimplexTree()
+
+	if added := st.Insert(2, 1, 0); len(added) != 7 {
+		t.Fatalf("expected 7 new simplices, got %d: %v", len(added), added)
+	}
+	if added := st.Insert(1, 2, 3); len(added) != 4 {
+		t.Fatalf("expected 4 new simplices, got %d: %v", len(added), added)
+	}
+
+	if !st.Contains(2, 0) || st.Contains(0, 3) {
+		t.Error("invalid membership")
+	}
+
+	if count := st.Count(1); count != 5 {
+		t.Errorf("expected 5 edges, got %d", count)
+	}
+
+	if n := st.Len(); n != 11 {
+		t.Errorf("expected 11 simplices, got %d", n)
+	}
+
+	edges := st.Simplices(1)
+	if len(edges) != 5 || edges[0][0] != 0 || edges[0][1] != 1 {
+		t.Errorf("expected edges in lexicographic order, got %v", edges)
+	}
+
+	if cf := st.Cofaces(1, 2); len(cf) != 2 {
+		t.Errorf("expected 2 cofaces of [1 2], got %v", cf)
+	}
+
+	if cf := st.Cofaces(1); len(cf) != 5 {
+		t.Errorf("expected 5 cofaces of [1], got %v", cf)
+	}
+
+	if star := st.Star(3); len(star) != 4 {
+		t.Errorf("expected 4 simplices in the star of [3], got %v", star)
+	}
+
+	// The link of the shared edge is the pair of opposite vertices
+	link := st.Link(1, 2)
+	if len(link) != 2 {
+		t.Fatalf("expected 2 simplices in the link of [1 2], got %v", link)
+	}
+	for _, b := range link {
+		if len(b) != 1 || (b[0] != 0 && b[0] != 3) {
+			t.Errorf("unexpected simplex %v in the link of [1 2]", b)
+		}
+	}
+
+	if max := st.MaximalSimplices(); len(max) != 2 {
+		t.Errorf("expected 2 maximal simplices, got %v", max)
+	}
+}
+
+func TestNewComplexFromSimplexTree(t *testing.T) {
+	st := NewSimplexTree()
+	c := NewComplexFromSimplexTree(st)
+	c.NewSimplices([]Base{
+		{0, 1, 4}, {1, 4, 5}, {1, 2, 5}, {2, 5, 6}, {0, 2, 6}, {0, 4, 6},
+		{4, 5, 7}, {5, 7, 8}, {5, 6, 8}, {6, 8, 9}, {4, 6, 9}, {4, 7, 9},
+		{0, 7, 8}, {0, 1, 8}, {1, 8, 9}, {1, 2, 9}, {2, 7, 9}, {0, 2, 7},
+	}...)
+
+	if x := c.EulerChar(); x != 0 {
+		t.Fatalf("expected Euler char of torus to be 0, got %d", x)
+	}
+
+	expectedBN := []int{1, 2, 1}
+	bn := c.BettiNumbers()
+	if len(expectedBN) != len(bn) {
+		t.Fatalf("invalid number of Betti numbers: %v", bn)
+	}
+	for idx, ebn := range expectedBN {
+		if bn[idx] != ebn {
+			t.Fatalf("Betti number %d is wrong; expected %d received %d", idx, ebn, bn[idx])
+		}
+	}
+
+	smplx := c.GetSimplex(0)
+	if count := smplx.Cofaces(2).Card(); count != 6 {
+		t.Errorf("expected 6 triangles around [0], got %d", count)
+	}
+
+	if count := c.GetSimplex(0, 1, 4).Faces(0).Card(); count != 3 {
+		t.Errorf("expected 3 vertices, got %d", count)
+	}
+
+	if count := c.PrincipleSimplices().Card(); count != 18 {
+		t.Errorf("expected 18 principle simplices, got %d", count)
+	}
+}
+
+func TestNewComplexFromSimplexTree_Lazy(t *testing.T) {
+	st := NewSimplexTree()
+	if d := st.Dim(); d != 0 {
+		t.Fatalf("expected an empty tree to have dimension 0, got %d", d)
+	}
+
+	// A hollow tetrahedron and a disjoint circle
+	st.Insert(0, 1, 2)
+	st.Insert(0, 1, 3)
+	st.Insert(0, 2, 3)
+	st.Insert(1, 2, 3)
+	st.Insert(4, 5)
+	st.Insert(5, 6)
+	st.Insert(4, 6)
+
+	materialized := func() int {
+		var n int
+		for d := Dim(0); d <= st.Dim(); d++ {
+			st.walk(st.root, int(d)+1, func(node *stNode) {
+				if node.simplex != nil {
+					n++
+				}
+			})
+		}
+
+		return n
+	}
+
+	c := NewComplexFromSimplexTree(st)
+
+	for _, f := range []Field{Z2, Q} {
+		c.SetField(f)
+
+		expectedBN := []int{2, 1, 1}
+		bn := c.BettiNumbers()
+		for idx, ebn := range expectedBN {
+			if bn[idx] != ebn {
+				t.Fatalf("over %v, Betti number %d is wrong; expected %d received %d", f, idx, ebn, bn[idx])
+			}
+		}
+	}
+
+	if r := c.ChainGroup(1).Rank(); r != 9 {
+		t.Errorf("expected 9 edges, got %d", r)
+	}
+
+	if n := materialized(); n != 0 {
+		t.Fatalf("expected no simplices to be created for Betti numbers and ranks, got %d", n)
+	}
+
+	smplx := c.GetSimplex(1, 2)
+	if n := materialized(); n != 1 {
+		t.Fatalf("expected only the looked up simplex to be created, got %d", n)
+	}
+	if other := c.GetSimplexByIndex(smplx.Index(), 1); other != smplx {
+		t.Fatalf("expected %v by its index, got %v", smplx, other)
+	}
+
+	// Removing an edge of the tetrahedron, along with its two triangles, leaves a disk
+	c.RemoveSimplex(smplx)
+	if r := c.ChainGroup(1).Rank(); r != 8 {
+		t.Errorf("expected 8 edges after the removal, got %d", r)
+	}
+	expectedBN := []int{2, 1, 0}
+	bn := c.BettiNumbers()
+	for idx, ebn := range expectedBN {
+		if bn[idx] != ebn {
+			t.Fatalf("after the removal, Betti number %d is wrong; expected %d received %d", idx, ebn, bn[idx])
+		}
+	}
+
+	for idx, edge := range c.GetdSimplices(1) {
+		if edge.Index() != Index(idx) {
+			t.Fatalf("expected edges to be indexed consecutively, got %v at %d", edge.Index(), idx)
+		}
+		if !st.Contains(edge.Base()...) {
+			t.Errorf("edge %v isn't in the tree", edge)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package comptop
+
+import (
+	"fmt"
+	"math/big"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// SimplicialMap is a map between complexes which sends vertices to vertices such that the image of every Simplex is a Simplex.
+// The image of a Simplex may have a lower dimension when two of its vertices are sent to the same vertex.
+// A SimplicialMap induces a chain map between the chain groups of its domain and codomain, which in turn induces a linear map on homology.
+//
+// More info: https://en.wikipedia.org/wiki/Simplicial_map
+type SimplicialMap struct {
+	domain   *Complex
+	codomain *Complex
+
+	vertices map[Index]Index
+}
+
+// NewSimplicialMap returns the SimplicialMap from domain to codomain sending each vertex v of domain to vertices[v].
+// An error is returned if a vertex of domain is not mapped to a vertex of codomain, or if the image of a Simplex of domain is not a Simplex of codomain.
+func NewSimplicialMap(domain, codomain *Complex, vertices map[Index]Index) (*SimplicialMap, error) {
+	sm := &SimplicialMap{
+		domain:   domain,
+		codomain: codomain,
+		vertices: map[Index]Index{},
+	}
+
+	for _, v := range domain.GetdSimplices(0) {
+		w, exists := vertices[v.base[0]]
+		if !exists {
+			return nil, fmt.Errorf("comptop: NewSimplicialMap: vertex %d is not mapped", v.base[0])
+		}
+		if codomain.GetSimplex(w) == nil {
+			return nil, fmt.Errorf("comptop: NewSimplicialMap: vertex %d is mapped to %d, which is not in the codomain", v.base[0], w)
+		}
+
+		sm.vertices[v.base[0]] = w
+	}
+
+	// Faces of principle simplices map to faces of their images, so checking the principle simplices is enough
+	for smplx := range domain.PrincipleSimplices().set {
+		if sm.Image(smplx) == nil {
+			return nil, fmt.Errorf("comptop: NewSimplicialMap: the image of %v is not a simplex of the codomain", smplx.base)
+		}
+	}
+
+	return sm, nil
+}
+
+// Domain returns the Complex sm maps from.
+func (sm *SimplicialMap) Domain() *Complex {
+	return sm.domain
+}
+
+// Codomain returns the Complex sm maps to.
+func (sm *SimplicialMap) Codomain() *Complex {
+	return sm.codomain
+}
+
+// Vertex returns the image of the vertex v; the second return value is false if v is not a vertex of the domain.
+func (sm *SimplicialMap) Vertex(v Index) (Index, bool) {
+	w, exists := sm.vertices[v]
+	return w, exists
+}
+
+// Image returns the Simplex of the codomain spanned by the images of the vertices of s; returns nil if there is no such Simplex.
+func (sm *SimplicialMap) Image(s *Simplex) *Simplex {
+	base := make(Base, len(s.base))
+	for idx, v := range s.base {
+		w, exists := sm.vertices[v]
+		if !exists {
+			return nil
+		}
+		base[idx] = w
+	}
+
+	return sm.codomain.GetSimplex(base...)
+}
+
+// image returns the image of s along with the sign relating the orientations of s and its image.
+// The sign is 0 if s is collapsed onto a Simplex of lower dimension.
+func (sm *SimplicialMap) image(s *Simplex) (*Simplex, int) {
+	s.sort()
+
+	base := make(Base, len(s.base))
+	for idx, v := range s.base {
+		base[idx] = sm.vertices[v]
+	}
+
+	if len(normalizeBase(base)) != len(base) {
+		return nil, 0
+	}
+
+	return sm.codomain.GetSimplex(base...), permutationParity(base)
+}
+
+// ChainMap returns the image of c under the chain map induced by sm.
+// Each Simplex is sent to its image, with the sign of the permutation relating their orientations;
+// simplices whose image has a lower dimension are sent to 0.
+func (sm *SimplicialMap) ChainMap(c *Chain) *Chain {
+	if c == nil || c.complex != sm.domain {
+		return nil
+	}
+
+	group := sm.codomain.ChainGroup(c.dim)
+	if group == nil {
+		return nil
+	}
+
+	f := group.Field()
+	coeffs := map[*Simplex]*big.Rat{}
+	for _, smplx := range c.simplices {
+		img, sign := sm.image(smplx)
+		if sign == 0 {
+			continue
+		}
+
+		x := fieldMul(f, c.Coefficient(smplx), big.NewRat(int64(sign), 1))
+		if y, exists := coeffs[img]; exists {
+			x = fieldAdd(f, x, y)
+		}
+		coeffs[img] = x
+	}
+
+	return group.NewChainFromCoefficients(coeffs)
+}
+
+// ChainMapMatrix returns the matrix of the chain map induced by sm from the d-dimensional ChainGroup of the domain to that of the codomain.
+// Rows and columns follow the bases of the codomain and domain chain groups respectively.
+// Returns nil if either complex has no simplices of dimension d.
+func (sm *SimplicialMap) ChainMapMatrix(d Dim) mat.Matrix {
+	from := sm.domain.ChainGroup(d)
+	to := sm.codomain.ChainGroup(d)
+	if from == nil || to == nil || from.Rank() == 0 || to.Rank() == 0 {
+		return nil
+	}
+
+	from.sortIdxs()
+	to.sortIdxs()
+
+	rows := make(map[*Simplex]int, len(to.idxs))
+	for row, idx := range to.idxs {
+		rows[to.Simplex(idx)] = row
+	}
+
+	m := mat.NewDense(to.Rank(), from.Rank(), nil)
+	for col, idx := range from.idxs {
+		img, sign := sm.image(from.Simplex(idx))
+		if sign == 0 {
+			continue
+		}
+
+		if isBinary(sm.codomain.field) {
+			sign = 1
+		}
+		m.Set(rows[img], col, float64(sign))
+	}
+
+	return m
+}
+
+// HomologyMap returns the matrix of the linear map induced by sm on the homology groups of dimension d.
+// Entry [i] holds the coordinates of the class of the image of the i^th cycle with respect to the Basis of the HomologyGroup of the codomain.
+// If no cycles are given, the Basis of the HomologyGroup of the domain is used; MinimalBasis can be passed instead to track specific cycles.
+// An entry is nil if the corresponding chain is not a cycle.
+// Returns nil if either complex has no simplices of dimension d.
+func (sm *SimplicialMap) HomologyMap(d Dim, cycles ...*Chain) [][]*big.Rat {
+	from := sm.domain.ChainGroup(d)
+	to := sm.codomain.ChainGroup(d)
+	if from == nil || to == nil {
+		return nil
+	}
+
+	if len(cycles) == 0 {
+		cycles = from.HomologyGroup().Basis()
+	}
+
+	hg := to.HomologyGroup()
+
+	coords := [][]*big.Rat{}
+	for _, cycle := range cycles {
+		if cycle == nil || cycle.chaingroup != from || !cycle.IsCycle() {
+			coords = append(coords, nil)
+			continue
+		}
+
+		coords = append(coords, hg.Coordinates(sm.ChainMap(cycle)))
+	}
+
+	return coords
+}
+
+// HomologyRank returns the rank of the linear map induced by sm on the homology groups of dimension d.
+// The map is injective on homology when its rank is the d^th Betti number of the domain.
+func (sm *SimplicialMap) HomologyRank(d Dim) int {
+	cols := sm.HomologyMap(d)
+	if len(cols) == 0 {
+		return 0
+	}
+
+	return fieldRank(sm.codomain.Field(), cols...)
+}
@@ -0,0 +1,99 @@
+package comptop
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSimplicialMap(t *testing.T) {
+	hexagon := []Base{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {0, 5}}
+	triangle := []Base{{0, 1}, {1, 2}, {0, 2}}
+
+	// Wrapping the hexagon twice around the triangle
+	wrap := map[Index]Index{0: 0, 1: 1, 2: 2, 3: 0, 4: 1, 5: 2}
+
+	t.Run("degree 2 over Q", func(tt *testing.T) {
+		domain := &Complex{}
+		domain.SetField(Q)
+		domain.NewSimplices(hexagon...)
+
+		codomain := &Complex{}
+		codomain.SetField(Q)
+		codomain.NewSimplices(triangle...)
+
+		sm, err := NewSimplicialMap(domain, codomain, wrap)
+		if err != nil {
+			tt.Fatal(err)
+		}
+
+		coords := sm.HomologyMap(1)
+		if len(coords) != 1 || len(coords[0]) != 1 {
+			tt.Fatalf("expected a 1x1 matrix on H_1, got %v", coords)
+		}
+		if x := new(big.Rat).Abs(coords[0][0]); x.Cmp(big.NewRat(2, 1)) != 0 {
+			tt.Errorf("expected the map to have degree ±2, got %v", coords[0][0])
+		}
+
+		// The chain map commutes with the boundary
+		for _, edge := range domain.GetdSimplices(1) {
+			chain := domain.ChainGroup(1).NewChainFromSimplices(edge)
+			a := sm.ChainMap(chain.Boundary())
+			b := sm.ChainMap(chain).Boundary()
+			if !a.Equals(b) {
+				tt.Errorf("expected f(∂%v) = ∂f(%v), got %v and %v", edge, edge, a, b)
+			}
+		}
+	})
+
+	t.Run("degree 2 over Z_2", func(tt *testing.T) {
+		domain := &Complex{}
+		domain.NewSimplices(hexagon...)
+
+		codomain := &Complex{}
+		codomain.NewSimplices(triangle...)
+
+		sm, err := NewSimplicialMap(domain, codomain, wrap)
+		if err != nil {
+			tt.Fatal(err)
+		}
+
+		if r := sm.HomologyRank(1); r != 0 {
+			tt.Errorf("expected a map of even degree to vanish on H_1 over Z_2, got rank %d", r)
+		}
+	})
+
+	t.Run("identity", func(tt *testing.T) {
+		c := &Complex{}
+		c.NewSimplices(hexagon...)
+
+		identity := map[Index]Index{}
+		for v := Index(0); v < 6; v++ {
+			identity[v] = v
+		}
+
+		sm, err := NewSimplicialMap(c, c, identity)
+		if err != nil {
+			tt.Fatal(err)
+		}
+
+		if r := sm.HomologyRank(1); r != 1 {
+			tt.Errorf("expected the identity to be an isomorphism on H_1, got rank %d", r)
+		}
+	})
+
+	t.Run("invalid", func(tt *testing.T) {
+		domain := &Complex{}
+		domain.NewSimplices(hexagon...)
+
+		codomain := &Complex{}
+		codomain.NewSimplices([]Base{{0, 1}, {1, 2}}...)
+
+		if _, err := NewSimplicialMap(domain, codomain, wrap); err == nil {
+			tt.Error("expected an error when an edge is sent to a non-edge")
+		}
+
+		if _, err := NewSimplicialMap(domain, codomain, map[Index]Index{0: 0}); err == nil {
+			tt.Error("expected an error when a vertex is not mapped")
+		}
+	})
+}
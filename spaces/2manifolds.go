@@ -42,3 +42,16 @@ var MobiusStrip []comptop.Base = []comptop.Base{
 	{1, 4, 5},
 	{0, 1, 5},
 }
+
+var ProjectivePlane []comptop.Base = []comptop.Base{
+	{0, 1, 2},
+	{0, 2, 3},
+	{0, 3, 4},
+	{0, 4, 5},
+	{0, 1, 5},
+	{1, 2, 4},
+	{2, 3, 5},
+	{1, 3, 4},
+	{2, 4, 5},
+	{1, 3, 5},
+}
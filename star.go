@@ -0,0 +1,74 @@
+package comptop
+
+// Star returns the set of simplices that have s as a face, including s itself.
+// The star of a Simplex is generally not closed under taking faces, so it is returned as a SimplicialSet rather than a Complex.
+//
+// More info: https://en.wikipedia.org/wiki/Star_(simplicial_complex)
+func (s *Simplex) Star() *SimplicialSet {
+	star := NewSimplicialSet(s)
+	if cf := s.AllCofaces(); cf != nil {
+		star.Add(cf.Slice()...)
+	}
+
+	return star
+}
+
+// ClosedStar returns the smallest sub-Complex containing every Simplex in the star of s.
+// Each Simplex in the returned Complex carries the Data of the matching Simplex in the Complex of s.
+//
+// More info: https://en.wikipedia.org/wiki/Star_(simplicial_complex)
+func (s *Simplex) ClosedStar() *Complex {
+	bases := []Base{}
+	for _, smplx := range s.Star().Slice() {
+		bases = append(bases, smplx.Base())
+	}
+
+	return s.complex.subcomplex(bases)
+}
+
+// Link returns the sub-Complex made up of the simplices in the closed star of s which don't intersect s.
+// Equivalently, a Simplex t is in the link of s if t and s are disjoint and their union is a Simplex in the Complex of s.
+// Each Simplex in the returned Complex carries the Data of the matching Simplex in the Complex of s.
+//
+// More info: https://en.wikipedia.org/wiki/Link_(simplicial_complex)
+func (s *Simplex) Link() *Complex {
+	sortSimplices(s)
+
+	bases := []Base{}
+	if cf := s.AllCofaces(); cf != nil {
+		for _, coface := range cf.Slice() {
+			sortSimplices(coface)
+
+			// Remove the vertices of s from the coface
+			b := Base{}
+			idx := 0
+			for _, v := range coface.base {
+				if idx < len(s.base) && s.base[idx] == v {
+					idx++
+					continue
+				}
+				b = append(b, v)
+			}
+
+			bases = append(bases, b)
+		}
+	}
+
+	return s.complex.subcomplex(bases)
+}
+
+// subcomplex returns the Complex made up of the simplices of c with the given bases, along with all of their faces.
+func (c *Complex) subcomplex(bases []Base) *Complex {
+	sub := &Complex{}
+	sub.NewSimplices(bases...)
+
+	for d := Dim(0); d <= sub.dim; d++ {
+		for _, smplx := range sub.GetdSimplices(d) {
+			if orig := c.GetSimplex(smplx.base...); orig != nil {
+				smplx.Data = orig.Data
+			}
+		}
+	}
+
+	return sub
+}
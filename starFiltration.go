@@ -0,0 +1,72 @@
+package comptop
+
+import (
+	"math"
+	"sort"
+)
+
+// VertexFunc assigns a real value to each vertex of a Complex; it is the real-valued counterpart of CF.
+type VertexFunc func(Index) float64
+
+// VertexFunc returns f as a real-valued VertexFunc.
+func (f CF) VertexFunc() VertexFunc {
+	return func(v Index) float64 {
+		return float64(f(v))
+	}
+}
+
+// LowerStarFiltration returns the Filtration of c in which each Simplex is born at the largest value f takes on its vertices.
+// The sub-Complex born by time t is the lower excursion set {f <= t}, so the Filtration sweeps the sublevel sets of f upwards.
+//
+// More info: 'Computational Topology: An Introduction' by Edelsbrunner & Harer, pg 135.
+func (c *Complex) LowerStarFiltration(f VertexFunc) *Filtration {
+	return c.Filtration(func(s *Simplex) float64 {
+		t := math.Inf(-1)
+		for _, v := range s.base {
+			t = math.Max(t, f(v))
+		}
+
+		return t
+	})
+}
+
+// UpperStarFiltration returns the Filtration of c in which each Simplex is born at minus the smallest value f takes on its vertices.
+// The sub-Complex born by time -t is the upper excursion set {f >= t}, so the Filtration sweeps the superlevel sets of f downwards;
+// birth and death times of its persistence intervals are the negated values of f.
+func (c *Complex) UpperStarFiltration(f VertexFunc) *Filtration {
+	return c.LowerStarFiltration(func(v Index) float64 {
+		return -f(v)
+	})
+}
+
+// EulerCurve returns the Euler characteristic of the sub-Complex of simplices born by each time in grid.
+// The whole curve is computed in one pass over the Filtration, without building the sub-complexes.
+func (f *Filtration) EulerCurve(grid []float64) []int {
+	order := make([]int, len(grid))
+	for idx := range order {
+		order[idx] = idx
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return grid[order[i]] < grid[order[j]]
+	})
+
+	curve := make([]int, len(grid))
+
+	var (
+		chi  int
+		next int
+	)
+	for _, idx := range order {
+		for ; next < len(f.simplices) && f.births[f.simplices[next]] <= grid[idx]; next++ {
+			if f.simplices[next].Dim()%2 == 0 {
+				chi++
+			} else {
+				chi--
+			}
+		}
+
+		curve[idx] = chi
+	}
+
+	return curve
+}
@@ -0,0 +1,75 @@
+package comptop
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComplex_LowerStarFiltration(t *testing.T) {
+	c := &Complex{}
+	c.NewSimplices([]Base{{0, 1, 2}, {0, 2, 3}, {2, 3, 4}, {1, 4}, {4, 5}}...)
+
+	heights := []int{3, 0, 2, 1, 4, 0}
+	cf := CF(func(v Index) int { return heights[v] })
+
+	lower := c.LowerStarFiltration(cf.VertexFunc())
+	upper := c.UpperStarFiltration(cf.VertexFunc())
+
+	for _, smplx := range c.GetdSimplices(2) {
+		max, min := math.Inf(-1), math.Inf(1)
+		for _, v := range smplx.Base() {
+			max = math.Max(max, float64(heights[v]))
+			min = math.Min(min, float64(heights[v]))
+		}
+		if b := lower.Birth(smplx); b != max {
+			t.Errorf("expected %v to be born at %v in the lower star filtration, got %v", smplx, max, b)
+		}
+		if b := upper.Birth(smplx); b != -min {
+			t.Errorf("expected %v to be born at %v in the upper star filtration, got %v", smplx, -min, b)
+		}
+	}
+
+	// The Euler curves agree with the excursion sets built one threshold at a time
+	grid := []float64{}
+	for s := -1; s <= 5; s++ {
+		grid = append(grid, float64(s))
+	}
+
+	lowerCurve := lower.EulerCurve(grid)
+	for idx, s := range grid {
+		expected := c.LowerExcursionSet(cf, int(s)+1).EulerChar()
+		if lowerCurve[idx] != expected {
+			t.Errorf("expected chi({f <= %v}) = %d, got %d", s, expected, lowerCurve[idx])
+		}
+	}
+
+	negated := make([]float64, len(grid))
+	for idx, s := range grid {
+		negated[idx] = -s
+	}
+	upperCurve := upper.EulerCurve(negated)
+	for idx, s := range grid {
+		expected := c.UpperExcursionSet(cf, int(s)-1).EulerChar()
+		if upperCurve[idx] != expected {
+			t.Errorf("expected chi({f >= %v}) = %d, got %d", s, expected, upperCurve[idx])
+		}
+	}
+
+	// Minima at vertices 1, 3 and 5 start components; vertex 2 joins 3 to 1 at height 2 and vertex 4 joins 5 at height 4
+	b0 := lower.Barcode(0)
+	if len(b0) != 3 {
+		t.Fatalf("expected 3 intervals in dimension 0, got %v", b0)
+	}
+	expected := map[[2]float64]bool{{0, math.Inf(1)}: true, {1, 2}: true, {0, 4}: true}
+	for _, pi := range b0 {
+		if !expected[[2]float64{pi.Birth, pi.Death}] {
+			t.Errorf("unexpected interval %v", pi)
+		}
+	}
+
+	// Edge [1 4] closes a loop through vertex 2 which the triangles never fill
+	b1 := lower.Barcode(1)
+	if len(b1) != 1 || !b1[0].IsEssential() || b1[0].Birth != 4 {
+		t.Errorf("expected an essential loop born at 4, got %v", b1)
+	}
+}
@@ -0,0 +1,45 @@
+package comptop
+
+import "testing"
+
+func TestSimplex_Link(t *testing.T) {
+	c := &Complex{}
+	c.NewSimplices([]Base{
+		{0, 1, 4}, {1, 4, 5}, {1, 2, 5}, {2, 5, 6}, {0, 2, 6}, {0, 4, 6},
+		{4, 5, 7}, {5, 7, 8}, {5, 6, 8}, {6, 8, 9}, {4, 6, 9}, {4, 7, 9},
+		{0, 7, 8}, {0, 1, 8}, {1, 8, 9}, {1, 2, 9}, {2, 7, 9}, {0, 2, 7},
+	}...)
+
+	vertex := c.GetSimplex(0)
+
+	if count := vertex.Star().Card(); count != 13 {
+		t.Errorf("expected 13 simplices in the star of a vertex, got %d", count)
+	}
+
+	closedStar := vertex.ClosedStar()
+	if x := closedStar.EulerChar(); x != 1 {
+		t.Errorf("expected the closed star to be contractible, got Euler char %d", x)
+	}
+
+	// Every vertex of the torus is a manifold point, so its link is a circle
+	link := vertex.Link()
+	if link.GetSimplex(0) != nil {
+		t.Error("link contains the vertex itself")
+	}
+
+	expectedBN := []int{1, 1}
+	bn := link.BettiNumbers()
+	if len(expectedBN) != len(bn) {
+		t.Fatalf("invalid number of Betti numbers: %v", bn)
+	}
+	for idx, ebn := range expectedBN {
+		if bn[idx] != ebn {
+			t.Fatalf("Betti number %d is wrong; expected %d received %d", idx, ebn, bn[idx])
+		}
+	}
+
+	// The link of an edge on the torus is the pair of vertices opposite it
+	if count := c.GetSimplex(0, 1).Link().ChainGroup(0).Rank(); count != 2 {
+		t.Errorf("expected 2 vertices in the link of an edge, got %d", count)
+	}
+}
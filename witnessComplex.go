@@ -0,0 +1,322 @@
+package comptop
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// MaxMinLandmarks returns the indices of n points chosen by farthest point sampling, starting with the point at index first:
+// each new landmark is the point whose distance to the landmarks chosen so far is the largest.
+// Maxmin landmarks spread out evenly over the sample; if n is larger than the number of points, every point is returned.
+// MaxMinLandmarks panics if first is not the index of a point.
+//
+// More info: 'Topological estimation using witness complexes' by de Silva & Carlsson.
+func MaxMinLandmarks(points [][]float64, n int, first int) []int {
+	if first < 0 || first >= len(points) {
+		panic(fmt.Sprintf("comptop: MaxMinLandmarks: no point with index %d", first))
+	}
+	if n > len(points) {
+		n = len(points)
+	}
+
+	landmarks := make([]int, 0, n)
+	if n <= 0 {
+		return landmarks
+	}
+
+	dist := make([]float64, len(points))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+	}
+
+	next := first
+	for len(landmarks) < n {
+		landmark := points[next]
+		landmarks = append(landmarks, next)
+
+		// Update the distance of each point to its closest landmark, and pick the farthest point
+		far := -1.0
+		for i, p := range points {
+			if d := math.Sqrt(squaredDistance(p, landmark)); d < dist[i] {
+				dist[i] = d
+			}
+			if dist[i] > far {
+				far = dist[i]
+				next = i
+			}
+		}
+	}
+
+	return landmarks
+}
+
+// RandomLandmarks returns the indices of n points chosen uniformly at random without replacement, using rng.
+// If rng is nil, the default source of the math/rand package is used.
+// If n is larger than the number of points, every point is returned.
+func RandomLandmarks(points [][]float64, n int, rng *rand.Rand) []int {
+	if n > len(points) {
+		n = len(points)
+	}
+	if n <= 0 {
+		return []int{}
+	}
+
+	perm := rand.Perm
+	if rng != nil {
+		perm = rng.Perm
+	}
+
+	return perm(len(points))[:n]
+}
+
+// StrongWitnessComplex returns the strong witness complex at relaxation alpha of the points, on the landmarks given by their indices.
+// The i^th landmark becomes the 0-simplex with Index i, and a set of landmarks spans a Simplex if some point w witnesses it strongly:
+// every one of them is within m(w) + alpha of w, where m(w) is the distance from w to its closest landmark.
+// Simplices of dimension larger than maxDim are not constructed.
+// The Data field of each vertex holds the index, as an int, of the point the landmark came from;
+// that of every other Simplex holds, as a float64, the smallest relaxation at which it appears.
+//
+// More info: 'Topological estimation using witness complexes' by de Silva & Carlsson.
+func StrongWitnessComplex(points [][]float64, landmarks []int, alpha float64, maxDim Dim) *Complex {
+	if len(landmarks) == 0 {
+		return &Complex{}
+	}
+
+	values := map[string]float64{}
+	simplices := map[string]Base{}
+
+	for _, w := range points {
+		dist, order := landmarkDistances(points, landmarks, w)
+		m := dist[order[0]]
+
+		near := Base{}
+		for _, l := range order {
+			if dist[l] > m+alpha {
+				break
+			}
+			near = append(near, Index(l))
+		}
+		sort.Sort(near)
+
+		for k := 2; k <= int(maxDim)+1 && k <= len(near); k++ {
+			for _, b := range subsets(near, k) {
+				value := math.Max(0, maxDistance(dist, b)-m)
+				recordWitness(values, simplices, b, value)
+			}
+		}
+	}
+
+	return witnessComplex(landmarks, values, simplices)
+}
+
+// WeakWitnessComplex returns the weak witness complex at relaxation alpha of the points, on the landmarks given by their indices.
+// A set of landmarks is weakly witnessed by a point w if each of them is within alpha of being closer to w than every other landmark:
+// no landmark in the set is further from w than alpha plus the distance from w to the closest landmark outside the set.
+// A set spans a Simplex if it and each of its faces are weakly witnessed.
+// Simplices of dimension larger than maxDim are not constructed.
+// The Data field of each vertex holds the index, as an int, of the point the landmark came from;
+// that of every other Simplex holds, as a float64, the smallest relaxation at which it appears.
+//
+// More info: 'Topological estimation using witness complexes' by de Silva & Carlsson.
+func WeakWitnessComplex(points [][]float64, landmarks []int, alpha float64, maxDim Dim) *Complex {
+	if len(landmarks) == 0 {
+		return &Complex{}
+	}
+
+	values := map[string]float64{}
+	simplices := map[string]Base{}
+
+	for _, w := range points {
+		dist, order := landmarkDistances(points, landmarks, w)
+
+		for k := 2; k <= int(maxDim)+1 && k < len(order); k++ {
+			// Among the k+1 closest landmarks, one is outside any set of k landmarks
+			bound := dist[order[k]] + alpha
+
+			near := Base{}
+			for _, l := range order {
+				if dist[l] > bound {
+					break
+				}
+				near = append(near, Index(l))
+			}
+			sort.Sort(near)
+
+			for _, b := range subsets(near, k) {
+				outside := math.Inf(1)
+				for _, l := range order {
+					if !b.has(Index(l)) {
+						outside = dist[l]
+						break
+					}
+				}
+
+				if value := math.Max(0, maxDistance(dist, b)-outside); value <= alpha {
+					recordWitness(values, simplices, b, value)
+				}
+			}
+		}
+	}
+
+	// With every landmark in the set, nothing is outside, so any point witnesses it
+	if k := len(landmarks); len(points) > 0 && k >= 2 && k <= int(maxDim)+1 {
+		b := make(Base, k)
+		for idx := range b {
+			b[idx] = Index(idx)
+		}
+		recordWitness(values, simplices, b, 0)
+	}
+
+	// Keep the witnessed sets all of whose faces are witnessed, raising values so faces come first
+	for k := 3; k <= int(maxDim)+1; k++ {
+		for key, b := range simplices {
+			if len(b) != k {
+				continue
+			}
+
+			for _, facet := range subsets(b, k-1) {
+				fv, witnessed := values[(&simplex{base: facet}).key()]
+				if !witnessed {
+					delete(simplices, key)
+					delete(values, key)
+					break
+				}
+				values[key] = math.Max(values[key], fv)
+			}
+		}
+	}
+
+	return witnessComplex(landmarks, values, simplices)
+}
+
+// LazyWitnessComplex returns the lazy witness complex with parameter nu at scale r of the points, on the landmarks given by their indices.
+// Two landmarks a and b are joined by an edge if some point w has max(d(a, w), d(b, w)) <= m_nu(w) + r,
+// where m_nu(w) is the distance from w to its nu^th closest landmark (and 0 when nu is 0);
+// larger sets of landmarks span a Simplex when each pair of them is joined, as in a Vietoris-Rips complex.
+// Simplices of dimension larger than maxDim are not constructed.
+// The Data field of each vertex holds the index, as an int, of the point the landmark came from;
+// that of every other Simplex holds, as a float64, the smallest scale at which it appears.
+// LazyWitnessComplex panics if nu is negative.
+//
+// More info: 'Topological estimation using witness complexes' by de Silva & Carlsson.
+func LazyWitnessComplex(points [][]float64, landmarks []int, nu int, r float64, maxDim Dim) *Complex {
+	if nu < 0 {
+		panic(fmt.Sprintf("comptop: LazyWitnessComplex: nu must not be negative, got %d", nu))
+	}
+
+	n := len(landmarks)
+	if n == 0 {
+		return &Complex{}
+	}
+
+	scales := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			scales.SetSym(i, j, math.Inf(1))
+		}
+	}
+
+	for _, w := range points {
+		dist, order := landmarkDistances(points, landmarks, w)
+
+		var m float64
+		if nu > 0 {
+			m = dist[order[len(order)-1]]
+			if nu <= n {
+				m = dist[order[nu-1]]
+			}
+		}
+
+		near := []int{}
+		for _, l := range order {
+			if dist[l] > m+r {
+				break
+			}
+			near = append(near, l)
+		}
+
+		for i, a := range near {
+			for _, b := range near[i+1:] {
+				value := math.Max(0, math.Max(dist[a], dist[b])-m)
+				if value < scales.At(a, b) {
+					scales.SetSym(a, b, value)
+				}
+			}
+		}
+	}
+
+	c := RipsComplexFromDistances(scales, r, maxDim)
+	for _, v := range c.GetdSimplices(0) {
+		v.Data = landmarks[v.base[0]]
+	}
+
+	return c
+}
+
+// landmarkDistances returns the distances from p to each landmark, along with the landmark positions sorted by distance.
+func landmarkDistances(points [][]float64, landmarks []int, p []float64) ([]float64, []int) {
+	dist := make([]float64, len(landmarks))
+	order := make([]int, len(landmarks))
+	for idx, l := range landmarks {
+		dist[idx] = math.Sqrt(squaredDistance(p, points[l]))
+		order[idx] = idx
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		return dist[order[i]] < dist[order[j]]
+	})
+
+	return dist, order
+}
+
+// maxDistance returns the largest of the distances to the landmarks in b.
+func maxDistance(dist []float64, b Base) float64 {
+	var max float64
+	for _, l := range b {
+		max = math.Max(max, dist[l])
+	}
+
+	return max
+}
+
+// recordWitness keeps the smallest value found for the set of landmarks b.
+func recordWitness(values map[string]float64, simplices map[string]Base, b Base, value float64) {
+	key := (&simplex{base: b}).key()
+	if old, exists := values[key]; exists && old <= value {
+		return
+	}
+
+	values[key] = value
+	simplices[key] = b
+}
+
+// witnessComplex returns the Complex on the landmarks spanned by the witnessed sets, with their values as Data.
+func witnessComplex(landmarks []int, values map[string]float64, simplices map[string]Base) *Complex {
+	c := &Complex{}
+
+	bases := []Base{}
+	for i := range landmarks {
+		bases = append(bases, Base{Index(i)})
+	}
+	for _, b := range simplices {
+		bases = append(bases, b)
+	}
+	c.NewSimplices(bases...)
+
+	for d := Dim(0); d <= c.dim; d++ {
+		for _, smplx := range c.GetdSimplices(d) {
+			if d == 0 {
+				smplx.Data = landmarks[smplx.base[0]]
+				continue
+			}
+
+			smplx.Data = values[smplx.key()]
+		}
+	}
+
+	return c
+}
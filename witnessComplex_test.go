@@ -0,0 +1,115 @@
+package comptop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMaxMinLandmarks(t *testing.T) {
+	points := [][]float64{}
+	for x := 0; x <= 10; x++ {
+		points = append(points, []float64{float64(x)})
+	}
+
+	landmarks := MaxMinLandmarks(points, 3, 0)
+	expected := []int{0, 10, 5}
+	if len(landmarks) != len(expected) {
+		t.Fatalf("expected landmarks %v, got %v", expected, landmarks)
+	}
+	for idx, l := range expected {
+		if landmarks[idx] != l {
+			t.Errorf("expected landmarks %v, got %v", expected, landmarks)
+			break
+		}
+	}
+
+	if count := len(MaxMinLandmarks(points, 20, 3)); count != len(points) {
+		t.Errorf("expected every point to be a landmark, got %d", count)
+	}
+}
+
+func TestRandomLandmarks(t *testing.T) {
+	points := make([][]float64, 50)
+	for i := range points {
+		points[i] = []float64{float64(i)}
+	}
+
+	landmarks := RandomLandmarks(points, 10, rand.New(rand.NewSource(7)))
+	if len(landmarks) != 10 {
+		t.Fatalf("expected 10 landmarks, got %d", len(landmarks))
+	}
+
+	seen := map[int]bool{}
+	for _, l := range landmarks {
+		if l < 0 || l >= len(points) || seen[l] {
+			t.Errorf("expected distinct point indices, got %v", landmarks)
+		}
+		seen[l] = true
+	}
+}
+
+func TestWitnessComplex(t *testing.T) {
+	// A dense sample of the unit circle, with evenly spread landmarks
+	points := [][]float64{}
+	for k := 0; k < 240; k++ {
+		theta := 2 * math.Pi * float64(k) / 240
+		points = append(points, []float64{math.Cos(theta), math.Sin(theta)})
+	}
+	landmarks := MaxMinLandmarks(points, 12, 0)
+
+	// Large enough relaxations give the full 2-skeleton on the 12 landmarks, whose second Betti number is 11 choose 3
+	table := []struct {
+		name       string
+		complex    *Complex
+		expectedBN []int
+	}{
+		{"strong", StrongWitnessComplex(points, landmarks, 0.1, 2), []int{1, 1}},
+		{"strong filled", StrongWitnessComplex(points, landmarks, 2, 2), []int{1, 0, 165}},
+		{"weak", WeakWitnessComplex(points, landmarks, 0, 2), []int{1, 1}},
+		{"weak filled", WeakWitnessComplex(points, landmarks, 2, 2), []int{1, 0, 165}},
+		{"lazy", LazyWitnessComplex(points, landmarks, 1, 0.1, 2), []int{1, 1}},
+		{"lazy filled", LazyWitnessComplex(points, landmarks, 1, 3, 2), []int{1, 0, 165}},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(tt *testing.T) {
+			c := row.complex
+
+			bn := c.BettiNumbers()
+			if len(bn) != len(row.expectedBN) {
+				tt.Fatalf("expected Betti numbers %v, got %v", row.expectedBN, bn)
+			}
+			for idx, ebn := range row.expectedBN {
+				if bn[idx] != ebn {
+					tt.Errorf("expected Betti numbers %v, got %v", row.expectedBN, bn)
+					break
+				}
+			}
+
+			for i, l := range landmarks {
+				v := c.GetSimplex(Index(i))
+				if v == nil || v.Data.(int) != l {
+					tt.Errorf("expected vertex %d to come from point %d, got %v", i, l, v)
+				}
+			}
+
+			for d := Dim(1); d <= c.dim; d++ {
+				for _, smplx := range c.GetdSimplices(d) {
+					value := smplx.Data.(float64)
+					if value < 0 {
+						tt.Errorf("expected a non-negative value for %v, got %v", smplx, value)
+					}
+					if d < 2 {
+						continue
+					}
+					for _, facet := range facetsOf(smplx) {
+						if facet.Data.(float64) > value {
+							tt.Errorf("expected %v to appear before %v", facet, smplx)
+						}
+					}
+				}
+			}
+		})
+	}
+}